@@ -0,0 +1,165 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genericactuator
+
+import (
+	"context"
+	"fmt"
+
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	"github.com/gardener/gardener-extensions/pkg/controller/backupbucket"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+)
+
+type actuator struct {
+	backupBucketDelegate BackupBucketDelegate
+	lifecyclePolicy      LifecyclePolicy
+	client               client.Client
+	logger               logr.Logger
+}
+
+// InjectClient injects the given client into the actuator.
+func (a *actuator) InjectClient(client client.Client) error {
+	a.client = client
+	return nil
+}
+
+// InjectFunc enables injecting Kubernetes dependencies into actuator's dependencies.
+func (a *actuator) InjectFunc(f inject.Func) error {
+	return f(a.backupBucketDelegate)
+}
+
+// NewActuator creates a new Actuator that creates and deletes the buckets of the handled BackupBucket resources with
+// the given delegate, applies the given lifecycle policy to them and manages the secrets generated for them.
+func NewActuator(backupBucketDelegate BackupBucketDelegate, lifecyclePolicy LifecyclePolicy, logger logr.Logger) backupbucket.Actuator {
+	return &actuator{
+		backupBucketDelegate: backupBucketDelegate,
+		lifecyclePolicy:      lifecyclePolicy,
+		logger:               logger,
+	}
+}
+
+// Reconcile reconciles the update of a BackupBucket. The lifecycle policy is validated before the bucket is created, so
+// that an invalid policy does not leave a bucket without it behind.
+func (a *actuator) Reconcile(ctx context.Context, bb *extensionsv1alpha1.BackupBucket) error {
+	if err := a.lifecyclePolicy.Validate(); err != nil {
+		return fmt.Errorf("invalid lifecycle policy for bucket %s: %v", bb.Name, err)
+	}
+
+	credentials, err := a.credentials(ctx, bb)
+	if err != nil {
+		return err
+	}
+
+	if err := a.backupBucketDelegate.CreateBucket(ctx, bb, credentials); err != nil {
+		return fmt.Errorf("could not create bucket %s: %v", bb.Name, err)
+	}
+
+	if err := a.backupBucketDelegate.ApplyLifecyclePolicy(ctx, bb, credentials, a.lifecyclePolicy); err != nil {
+		return fmt.Errorf("could not apply lifecycle policy to bucket %s: %v", bb.Name, err)
+	}
+
+	generatedSecretData, err := a.backupBucketDelegate.GenerateCredentials(ctx, bb, credentials)
+	if err != nil {
+		return fmt.Errorf("could not generate credentials for bucket %s: %v", bb.Name, err)
+	}
+
+	if len(generatedSecretData) == 0 {
+		return a.deleteGeneratedSecret(ctx, bb)
+	}
+	return a.deployGeneratedSecret(ctx, bb, generatedSecretData)
+}
+
+// Delete deletes the BackupBucket.
+func (a *actuator) Delete(ctx context.Context, bb *extensionsv1alpha1.BackupBucket) error {
+	credentials, err := a.credentials(ctx, bb)
+	if err != nil {
+		return err
+	}
+
+	if err := a.backupBucketDelegate.DeleteBucket(ctx, bb, credentials); err != nil {
+		return fmt.Errorf("could not delete bucket %s: %v", bb.Name, err)
+	}
+
+	return a.deleteGeneratedSecret(ctx, bb)
+}
+
+func (a *actuator) credentials(ctx context.Context, bb *extensionsv1alpha1.BackupBucket) (map[string][]byte, error) {
+	secret, err := extensionscontroller.GetSecretByReference(ctx, a.client, &bb.Spec.SecretRef)
+	if err != nil {
+		a.logger.Error(err, "failed to read backup bucket secret", "backupbucket", bb.Name)
+		return nil, err
+	}
+	return secret.Data, nil
+}
+
+func (a *actuator) deployGeneratedSecret(ctx context.Context, bb *extensionsv1alpha1.BackupBucket, data map[string][]byte) error {
+	generatedSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GeneratedSecretNamePrefix + bb.Name,
+			Namespace: bb.Spec.SecretRef.Namespace,
+		},
+	}
+
+	if _, err := controllerutil.CreateOrUpdate(ctx, a.client, generatedSecret, func() error {
+		generatedSecret.Data = data
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if ref := bb.Status.GeneratedSecretRef; ref != nil && ref.Name == generatedSecret.Name && ref.Namespace == generatedSecret.Namespace {
+		return nil
+	}
+
+	return extensionscontroller.TryUpdateStatus(ctx, retry.DefaultBackoff, a.client, bb, func() error {
+		bb.Status.GeneratedSecretRef = &corev1.SecretReference{
+			Name:      generatedSecret.Name,
+			Namespace: generatedSecret.Namespace,
+		}
+		return nil
+	})
+}
+
+func (a *actuator) deleteGeneratedSecret(ctx context.Context, bb *extensionsv1alpha1.BackupBucket) error {
+	ref := bb.Status.GeneratedSecretRef
+	if ref == nil {
+		return nil
+	}
+
+	generatedSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ref.Name,
+			Namespace: ref.Namespace,
+		},
+	}
+	if err := a.client.Delete(ctx, generatedSecret); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	return extensionscontroller.TryUpdateStatus(ctx, retry.DefaultBackoff, a.client, bb, func() error {
+		bb.Status.GeneratedSecretRef = nil
+		return nil
+	})
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genericactuator_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/backupbucket"
+	"github.com/gardener/gardener-extensions/pkg/controller/backupbucket/genericactuator"
+	"github.com/gardener/gardener-extensions/pkg/controller/backupbucket/genericactuator/memory"
+	mockgenericactuator "github.com/gardener/gardener-extensions/pkg/mock/gardener-extensions/controller/backupbucket/genericactuator"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+)
+
+const (
+	providerSecretName      = "backupprovider"
+	providerSecretNamespace = "garden"
+	bucketName              = "test-bucket"
+)
+
+var _ = Describe("Actuator", func() {
+	var (
+		ctrl *gomock.Controller
+		ctx  = context.TODO()
+
+		c      client.Client
+		bb     *extensionsv1alpha1.BackupBucket
+		policy = genericactuator.LifecyclePolicy{ExpireObjectsAfter: 30 * 24 * time.Hour}
+
+		providerSecretData = map[string][]byte{"foo": []byte("bar")}
+		generatedSecretKey = client.ObjectKey{Namespace: providerSecretNamespace, Name: genericactuator.GeneratedSecretNamePrefix + bucketName}
+
+		logger = log.Log.WithName("test")
+	)
+
+	newActuator := func(delegate genericactuator.BackupBucketDelegate) backupbucket.Actuator {
+		a := genericactuator.NewActuator(delegate, policy, logger)
+		Expect(a.(inject.Client).InjectClient(c)).To(Succeed())
+		return a
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+
+		bb = &extensionsv1alpha1.BackupBucket{
+			ObjectMeta: metav1.ObjectMeta{Name: bucketName},
+			Spec: extensionsv1alpha1.BackupBucketSpec{
+				SecretRef: corev1.SecretReference{Name: providerSecretName, Namespace: providerSecretNamespace},
+			},
+		}
+
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(extensionsv1alpha1.AddToScheme(scheme)).To(Succeed())
+		c = fakeclient.NewFakeClientWithScheme(
+			scheme,
+			bb.DeepCopy(),
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: providerSecretName, Namespace: providerSecretNamespace},
+				Data:       providerSecretData,
+			},
+		)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Describe("#Reconcile", func() {
+		It("should create the bucket with the lifecycle policy and the generated secret", func() {
+			store := memory.NewObjectStore()
+
+			Expect(newActuator(store).Reconcile(ctx, bb)).To(Succeed())
+
+			Expect(store.HasBucket(bucketName)).To(BeTrue())
+			Expect(store.LifecyclePolicy(bucketName)).To(Equal(policy))
+
+			generatedSecret := &corev1.Secret{}
+			Expect(c.Get(ctx, generatedSecretKey, generatedSecret)).To(Succeed())
+			Expect(generatedSecret.Data).To(Equal(map[string][]byte{"bucketName": []byte(bucketName)}))
+
+			actual := &extensionsv1alpha1.BackupBucket{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: bucketName}, actual)).To(Succeed())
+			Expect(actual.Status.GeneratedSecretRef).To(Equal(&corev1.SecretReference{Name: generatedSecretKey.Name, Namespace: generatedSecretKey.Namespace}))
+		})
+
+		It("should delete the generated secret if no credentials are generated anymore", func() {
+			Expect(newActuator(memory.NewObjectStore()).Reconcile(ctx, bb)).To(Succeed())
+
+			delegate := mockgenericactuator.NewMockBackupBucketDelegate(ctrl)
+			delegate.EXPECT().CreateBucket(ctx, bb, providerSecretData)
+			delegate.EXPECT().ApplyLifecyclePolicy(ctx, bb, providerSecretData, policy)
+			delegate.EXPECT().GenerateCredentials(ctx, bb, providerSecretData)
+
+			Expect(newActuator(delegate).Reconcile(ctx, bb)).To(Succeed())
+
+			Expect(c.Get(ctx, generatedSecretKey, &corev1.Secret{})).To(BeNotFoundError())
+			Expect(bb.Status.GeneratedSecretRef).To(BeNil())
+		})
+
+		It("should fail if the bucket cannot be created", func() {
+			delegate := mockgenericactuator.NewMockBackupBucketDelegate(ctrl)
+			delegate.EXPECT().CreateBucket(ctx, bb, providerSecretData).Return(errors.New("quota exceeded"))
+
+			Expect(newActuator(delegate).Reconcile(ctx, bb)).To(MatchError(ContainSubstring("quota exceeded")))
+		})
+
+		It("should fail without creating the bucket if the lifecycle policy is invalid", func() {
+			policy.ImmutableFor = -time.Hour
+			defer func() { policy.ImmutableFor = 0 }()
+
+			delegate := mockgenericactuator.NewMockBackupBucketDelegate(ctrl)
+
+			Expect(newActuator(delegate).Reconcile(ctx, bb)).To(MatchError(ContainSubstring("invalid lifecycle policy")))
+		})
+	})
+
+	Describe("#Delete", func() {
+		It("should delete the bucket and the generated secret", func() {
+			store := memory.NewObjectStore()
+			a := newActuator(store)
+			Expect(a.Reconcile(ctx, bb)).To(Succeed())
+			Expect(store.PutObject(bucketName, memory.Object{Key: "foo/full-snapshot", Data: []byte("foo")})).To(Succeed())
+
+			Expect(a.Delete(ctx, bb)).To(Succeed())
+
+			Expect(store.HasBucket(bucketName)).To(BeFalse())
+			Expect(c.Get(ctx, generatedSecretKey, &corev1.Secret{})).To(BeNotFoundError())
+		})
+
+		It("should not delete buckets containing immutable objects", func() {
+			policy.ImmutableFor = 24 * time.Hour
+			defer func() { policy.ImmutableFor = 0 }()
+
+			store := memory.NewObjectStore()
+			a := newActuator(store)
+			Expect(a.Reconcile(ctx, bb)).To(Succeed())
+			Expect(store.PutObject(bucketName, memory.Object{Key: "foo/full-snapshot", LastModified: time.Now()})).To(Succeed())
+
+			Expect(a.Delete(ctx, bb)).To(MatchError(ContainSubstring("object foo/full-snapshot is immutable")))
+			Expect(store.HasBucket(bucketName)).To(BeTrue())
+
+			store.Now = func() time.Time { return time.Now().Add(25 * time.Hour) }
+			Expect(a.Delete(ctx, bb)).To(Succeed())
+		})
+	})
+})
+
+var _ = Describe("LifecyclePolicy", func() {
+	DescribeTable("#Validate",
+		func(policy genericactuator.LifecyclePolicy, matcher OmegaMatcher) {
+			Expect(policy.Validate()).To(matcher)
+		},
+		Entry("empty policy", genericactuator.LifecyclePolicy{}, Succeed()),
+		Entry("immutable objects which never expire", genericactuator.LifecyclePolicy{ImmutableFor: time.Hour}, Succeed()),
+		Entry("objects expiring after the lock", genericactuator.LifecyclePolicy{ExpireObjectsAfter: 2 * time.Hour, ImmutableFor: time.Hour}, Succeed()),
+		Entry("objects expiring while locked", genericactuator.LifecyclePolicy{ExpireObjectsAfter: time.Hour, ImmutableFor: 2 * time.Hour}, HaveOccurred()),
+		Entry("negative durations", genericactuator.LifecyclePolicy{ImmutableFor: -time.Hour}, HaveOccurred()),
+	)
+})
+
+func BeNotFoundError() OmegaMatcher {
+	return WithTransform(apierrors.IsNotFound, BeTrue())
+}
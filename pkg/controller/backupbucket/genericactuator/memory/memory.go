@@ -0,0 +1,280 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package memory contains an in-memory object store which implements the delegates of the generic backup actuators.
+// It is meant to be used in tests.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/backupbucket/genericactuator"
+	backupentrygenericactuator "github.com/gardener/gardener-extensions/pkg/controller/backupentry/genericactuator"
+	controllererror "github.com/gardener/gardener-extensions/pkg/controller/error"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+)
+
+// Object is an object in an ObjectStore.
+type Object struct {
+	// Key is the key of the object.
+	Key string
+	// Data is the content of the object.
+	Data []byte
+	// LastModified is the time the object was written.
+	LastModified time.Time
+}
+
+type bucket struct {
+	lifecyclePolicy genericactuator.LifecyclePolicy
+	objects         map[string]Object
+}
+
+// ObjectStore is an in-memory object store. The buckets are named like the BackupBuckets they belong to.
+// Objects which are immutable according to the lifecycle policy of their bucket can neither be overwritten nor
+// deleted, and buckets containing such objects cannot be deleted.
+type ObjectStore struct {
+	// Now returns the current time. It defaults to time.Now.
+	Now func() time.Time
+
+	lock    sync.RWMutex
+	buckets map[string]*bucket
+}
+
+var (
+	_ genericactuator.BackupBucketDelegate           = (*ObjectStore)(nil)
+	_ backupentrygenericactuator.BackupEntryDelegate = (*ObjectStore)(nil)
+	_ backupentrygenericactuator.BackupEntryVerifier = (*ObjectStore)(nil)
+)
+
+// NewObjectStore creates a new empty ObjectStore.
+func NewObjectStore() *ObjectStore {
+	return &ObjectStore{Now: time.Now, buckets: make(map[string]*bucket)}
+}
+
+// CreateBucket implements genericactuator.BackupBucketDelegate.
+func (s *ObjectStore) CreateBucket(_ context.Context, bb *extensionsv1alpha1.BackupBucket, _ map[string][]byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.buckets[bb.Name]; !ok {
+		s.buckets[bb.Name] = &bucket{objects: make(map[string]Object)}
+	}
+	return nil
+}
+
+// ApplyLifecyclePolicy implements genericactuator.BackupBucketDelegate.
+func (s *ObjectStore) ApplyLifecyclePolicy(_ context.Context, bb *extensionsv1alpha1.BackupBucket, _ map[string][]byte, policy genericactuator.LifecyclePolicy) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	b, ok := s.buckets[bb.Name]
+	if !ok {
+		return fmt.Errorf("bucket %s does not exist", bb.Name)
+	}
+	b.lifecyclePolicy = policy
+	return nil
+}
+
+// DeleteBucket implements genericactuator.BackupBucketDelegate.
+func (s *ObjectStore) DeleteBucket(_ context.Context, bb *extensionsv1alpha1.BackupBucket, _ map[string][]byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	b, ok := s.buckets[bb.Name]
+	if !ok {
+		return nil
+	}
+	for _, object := range b.objects {
+		if err := s.checkMutable(b, object); err != nil {
+			return err
+		}
+	}
+
+	delete(s.buckets, bb.Name)
+	return nil
+}
+
+// GenerateCredentials implements genericactuator.BackupBucketDelegate. The generated credentials only contain the
+// name of the bucket.
+func (s *ObjectStore) GenerateCredentials(_ context.Context, bb *extensionsv1alpha1.BackupBucket, _ map[string][]byte) (map[string][]byte, error) {
+	return map[string][]byte{"bucketName": []byte(bb.Name)}, nil
+}
+
+// Delete implements backupentrygenericactuator.BackupEntryDelegate. It deletes all objects stored under the prefix of
+// the given BackupEntry. If some of them are still immutable, a PendingError is returned which requeues the deletion
+// once they can be deleted.
+func (s *ObjectStore) Delete(_ context.Context, be *extensionsv1alpha1.BackupEntry) error {
+	if lockedUntil, now := s.lockedUntil(be.Spec.BucketName, entryPrefix(be)), s.Now(); lockedUntil.After(now) {
+		return &controllererror.PendingError{
+			Description:  fmt.Sprintf("Backups are immutable until %s", lockedUntil.UTC().Format(time.RFC3339)),
+			RequeueAfter: lockedUntil.Sub(now),
+		}
+	}
+	return s.DeleteObjects(be.Spec.BucketName, entryPrefix(be))
+}
+
+// lockedUntil returns the time until which objects of the bucket with the given name whose keys start with the given
+// prefix are immutable.
+func (s *ObjectStore) lockedUntil(name, prefix string) time.Time {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var lockedUntil time.Time
+	if b, ok := s.buckets[name]; ok {
+		for key, object := range b.objects {
+			if until := object.LastModified.Add(b.lifecyclePolicy.ImmutableFor); strings.HasPrefix(key, prefix) && until.After(lockedUntil) {
+				lockedUntil = until
+			}
+		}
+	}
+	return lockedUntil
+}
+
+// GetETCDSecretData implements backupentrygenericactuator.BackupEntryDelegate. It returns the given data unchanged.
+func (s *ObjectStore) GetETCDSecretData(_ context.Context, _ *extensionsv1alpha1.BackupEntry, data map[string][]byte) (map[string][]byte, error) {
+	return data, nil
+}
+
+// ListSnapshots implements backupentrygenericactuator.BackupEntryVerifier. Objects stored under the prefix of the given
+// BackupEntry are full snapshots if their names start with 'Full-' and delta snapshots if their names start with
+// 'Incr-', like the snapshots of etcd-backup-restore. All other objects are ignored.
+func (s *ObjectStore) ListSnapshots(_ context.Context, be *extensionsv1alpha1.BackupEntry, _ map[string][]byte) ([]backupentrygenericactuator.Snapshot, error) {
+	objects, err := s.ListObjects(be.Spec.BucketName, entryPrefix(be))
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []backupentrygenericactuator.Snapshot
+	for _, object := range objects {
+		var kind backupentrygenericactuator.SnapshotKind
+		switch name := path.Base(object.Key); {
+		case strings.HasPrefix(name, "Full-"):
+			kind = backupentrygenericactuator.SnapshotKindFull
+		case strings.HasPrefix(name, "Incr-"):
+			kind = backupentrygenericactuator.SnapshotKindDelta
+		default:
+			continue
+		}
+
+		snapshots = append(snapshots, backupentrygenericactuator.Snapshot{
+			Kind:         kind,
+			CreationTime: object.LastModified,
+			Size:         int64(len(object.Data)),
+		})
+	}
+	return snapshots, nil
+}
+
+func entryPrefix(be *extensionsv1alpha1.BackupEntry) string {
+	return be.Name + "/"
+}
+
+// HasBucket returns true if the bucket with the given name exists.
+func (s *ObjectStore) HasBucket(name string) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	_, ok := s.buckets[name]
+	return ok
+}
+
+// LifecyclePolicy returns the lifecycle policy of the bucket with the given name.
+func (s *ObjectStore) LifecyclePolicy(name string) (genericactuator.LifecyclePolicy, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	b, ok := s.buckets[name]
+	if !ok {
+		return genericactuator.LifecyclePolicy{}, fmt.Errorf("bucket %s does not exist", name)
+	}
+	return b.lifecyclePolicy, nil
+}
+
+// PutObject writes the given object to the bucket with the given name.
+func (s *ObjectStore) PutObject(name string, object Object) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	b, ok := s.buckets[name]
+	if !ok {
+		return fmt.Errorf("bucket %s does not exist", name)
+	}
+	if existing, ok := b.objects[object.Key]; ok {
+		if err := s.checkMutable(b, existing); err != nil {
+			return err
+		}
+	}
+	b.objects[object.Key] = object
+	return nil
+}
+
+// ListObjects returns the objects of the bucket with the given name whose keys start with the given prefix, sorted by
+// their keys.
+func (s *ObjectStore) ListObjects(name, prefix string) ([]Object, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	b, ok := s.buckets[name]
+	if !ok {
+		return nil, fmt.Errorf("bucket %s does not exist", name)
+	}
+
+	var objects []Object
+	for key, object := range b.objects {
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, object)
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+// DeleteObjects deletes the objects of the bucket with the given name whose keys start with the given prefix.
+func (s *ObjectStore) DeleteObjects(name, prefix string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	b, ok := s.buckets[name]
+	if !ok {
+		return fmt.Errorf("bucket %s does not exist", name)
+	}
+
+	for key, object := range b.objects {
+		if strings.HasPrefix(key, prefix) {
+			if err := s.checkMutable(b, object); err != nil {
+				return err
+			}
+		}
+	}
+	for key := range b.objects {
+		if strings.HasPrefix(key, prefix) {
+			delete(b.objects, key)
+		}
+	}
+	return nil
+}
+
+func (s *ObjectStore) checkMutable(b *bucket, object Object) error {
+	if lockedUntil := object.LastModified.Add(b.lifecyclePolicy.ImmutableFor); s.Now().Before(lockedUntil) {
+		return fmt.Errorf("object %s is immutable until %s", object.Key, lockedUntil.Format(time.RFC3339))
+	}
+	return nil
+}
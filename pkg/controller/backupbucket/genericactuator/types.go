@@ -0,0 +1,62 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genericactuator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+)
+
+// GeneratedSecretNamePrefix is the prefix of the name of the secret generated for a BackupBucket.
+const GeneratedSecretNamePrefix = "generated-bucket-"
+
+// LifecyclePolicy describes how objects are retained in a bucket.
+type LifecyclePolicy struct {
+	// ExpireObjectsAfter is the duration after which objects are deleted from the bucket. Zero means that objects
+	// never expire.
+	ExpireObjectsAfter time.Duration
+	// ImmutableFor is the duration for which objects cannot be modified or deleted after they were written, e.g. by
+	// an object lock. Zero means that objects are not locked.
+	ImmutableFor time.Duration
+}
+
+// Validate checks that objects do not expire while they are still locked.
+func (p LifecyclePolicy) Validate() error {
+	if p.ExpireObjectsAfter < 0 || p.ImmutableFor < 0 {
+		return fmt.Errorf("durations of lifecycle policy must not be negative")
+	}
+	if p.ExpireObjectsAfter > 0 && p.ExpireObjectsAfter < p.ImmutableFor {
+		return fmt.Errorf("objects must not expire after %s while they are immutable for %s", p.ExpireObjectsAfter, p.ImmutableFor)
+	}
+	return nil
+}
+
+// BackupBucketDelegate performs provider specific operations with the object store of BackupBucket resources.
+// All methods get the data of the secret referenced in the BackupBucket spec as credentials.
+type BackupBucketDelegate interface {
+	// CreateBucket creates the bucket of the given BackupBucket if it does not exist yet.
+	CreateBucket(context.Context, *extensionsv1alpha1.BackupBucket, map[string][]byte) error
+	// ApplyLifecyclePolicy applies the given lifecycle policy to the bucket of the given BackupBucket.
+	ApplyLifecyclePolicy(context.Context, *extensionsv1alpha1.BackupBucket, map[string][]byte, LifecyclePolicy) error
+	// DeleteBucket deletes the bucket of the given BackupBucket including all objects. It must not fail if the bucket
+	// does not exist.
+	DeleteBucket(context.Context, *extensionsv1alpha1.BackupBucket, map[string][]byte) error
+	// GenerateCredentials returns the data of the secret which is generated for the given BackupBucket, e.g.
+	// credentials which only grant access to its bucket. If no data is returned, no secret is generated.
+	GenerateCredentials(context.Context, *extensionsv1alpha1.BackupBucket, map[string][]byte) (map[string][]byte, error)
+}
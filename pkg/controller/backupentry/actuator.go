@@ -16,6 +16,7 @@ package backupentry
 
 import (
 	"context"
+	"time"
 
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 )
@@ -27,3 +28,11 @@ type Actuator interface {
 	// Delete deletes the BackupEntry.
 	Delete(context.Context, *extensionsv1alpha1.BackupEntry) error
 }
+
+// ResyncActuator can optionally be implemented by an Actuator whose status of successfully reconciled BackupEntry
+// resources gets outdated over time.
+type ResyncActuator interface {
+	// ResyncPeriod returns the duration after which a successfully reconciled BackupEntry is reconciled again.
+	// A duration of zero disables the periodic reconciliation.
+	ResyncPeriod() time.Duration
+}
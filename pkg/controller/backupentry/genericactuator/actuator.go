@@ -16,9 +16,12 @@ package genericactuator
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
 	"github.com/gardener/gardener-extensions/pkg/controller/backupentry"
+	controllererror "github.com/gardener/gardener-extensions/pkg/controller/error"
 
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
@@ -33,6 +36,8 @@ import (
 
 type actuator struct {
 	backupEntryDelegate BackupEntryDelegate
+	retentionPolicy     RetentionPolicy
+	maxSnapshotAge      time.Duration
 	client              client.Client
 	logger              logr.Logger
 }
@@ -48,17 +53,76 @@ func (a *actuator) InjectFunc(f inject.Func) error {
 	return f(a.backupEntryDelegate)
 }
 
+// Options are options for the generic BackupEntry actuator.
+type Options struct {
+	// RetentionPolicy determines how long the backups of deleted BackupEntries are retained.
+	RetentionPolicy RetentionPolicy
+	// MaxSnapshotAge is the age after which the newest snapshot of a BackupEntry is considered outdated if the
+	// delegate implements BackupEntryVerifier. Defaults to DefaultMaxSnapshotAge.
+	MaxSnapshotAge time.Duration
+}
+
 // NewActuator creates a new Actuator that updates the status of the handled BackupEntry resources.
 func NewActuator(backupEntryDelegate BackupEntryDelegate, logger logr.Logger) backupentry.Actuator {
+	return NewActuatorWithOptions(backupEntryDelegate, Options{}, logger)
+}
+
+// NewActuatorWithOptions creates a new Actuator that updates the status of the handled BackupEntry resources
+// according to the given options.
+func NewActuatorWithOptions(backupEntryDelegate BackupEntryDelegate, opts Options, logger logr.Logger) backupentry.Actuator {
+	maxSnapshotAge := opts.MaxSnapshotAge
+	if maxSnapshotAge == 0 {
+		maxSnapshotAge = DefaultMaxSnapshotAge
+	}
+
 	return &actuator{
 		logger:              logger,
 		backupEntryDelegate: backupEntryDelegate,
+		retentionPolicy:     opts.RetentionPolicy,
+		maxSnapshotAge:      maxSnapshotAge,
 	}
 }
 
+// ResyncPeriod returns a fraction of the maximum snapshot age if the delegate implements BackupEntryVerifier, so that
+// the ConditionTypeBackupsAvailable condition is kept up to date also without changes to the BackupEntry.
+func (a *actuator) ResyncPeriod() time.Duration {
+	if _, ok := a.backupEntryDelegate.(BackupEntryVerifier); !ok {
+		return 0
+	}
+	return a.maxSnapshotAge / backupsAvailableResyncsPerMaxSnapshotAge
+}
+
 // Reconcile reconciles the update of a BackupEntry
 func (a *actuator) Reconcile(ctx context.Context, be *extensionsv1alpha1.BackupEntry) error {
-	return a.deployEtcdBackupSecret(ctx, be)
+	if err := a.deployEtcdBackupSecret(ctx, be); err != nil {
+		return err
+	}
+
+	verifier, ok := a.backupEntryDelegate.(BackupEntryVerifier)
+	if !ok {
+		return nil
+	}
+
+	backupSecretData, err := a.getBackupSecretData(ctx, be)
+	if err != nil {
+		return err
+	}
+	return a.verifyBackups(ctx, verifier, be, backupSecretData)
+}
+
+func (a *actuator) getBackupSecretData(ctx context.Context, be *extensionsv1alpha1.BackupEntry) (map[string][]byte, error) {
+	backupSecret, err := extensionscontroller.GetSecretByReference(ctx, a.client, &be.Spec.SecretRef)
+	if err != nil {
+		a.logger.Error(err, "failed to read backup extension secret")
+		return nil, err
+	}
+
+	backupSecretData := backupSecret.DeepCopy().Data
+	if backupSecretData == nil {
+		backupSecretData = make(map[string][]byte)
+	}
+	backupSecretData[backupBucketName] = []byte(be.Spec.BucketName)
+	return backupSecretData, nil
 }
 
 func (a *actuator) deployEtcdBackupSecret(ctx context.Context, be *extensionsv1alpha1.BackupEntry) error {
@@ -77,14 +141,11 @@ func (a *actuator) deployEtcdBackupSecret(ctx context.Context, be *extensionsv1a
 		return nil
 	}
 
-	backupSecret, err := extensionscontroller.GetSecretByReference(ctx, a.client, &be.Spec.SecretRef)
+	backupSecretData, err := a.getBackupSecretData(ctx, be)
 	if err != nil {
-		a.logger.Error(err, "failed to read backup extension secret")
 		return err
 	}
 
-	backupSecretData := backupSecret.DeepCopy().Data
-	backupSecretData[backupBucketName] = []byte(be.Spec.BucketName)
 	etcdSecretData, err := a.backupEntryDelegate.GetETCDSecretData(ctx, be, backupSecretData)
 	if err != nil {
 		return err
@@ -104,7 +165,35 @@ func (a *actuator) deployEtcdBackupSecret(ctx context.Context, be *extensionsv1a
 	return err
 }
 
-// Delete deletes the BackupEntry
+// Delete deletes the BackupEntry. The backups are only purged after the retention policy allows it, until then the
+// time after which they are purged is tracked in the AnnotationPurgeAfter annotation and a PendingError is returned, so
+// that the deletion is reported as pending and requeued.
 func (a *actuator) Delete(ctx context.Context, be *extensionsv1alpha1.BackupEntry) error {
+	now := time.Now()
+	purgeTime := a.retentionPolicy.PurgeTime(be, now)
+
+	if remaining := purgeTime.Sub(now); remaining > 0 {
+		if err := a.trackPurgeTime(ctx, be, purgeTime); err != nil {
+			return err
+		}
+
+		a.logger.Info("Retaining backups of deleted backupentry", "backupentry", be.Name, "purgeAfter", purgeTime)
+		return &controllererror.PendingError{
+			Description:  fmt.Sprintf("Backups are retained until %s", purgeTime.UTC().Format(time.RFC3339)),
+			RequeueAfter: remaining,
+		}
+	}
+
 	return a.backupEntryDelegate.Delete(ctx, be)
 }
+
+func (a *actuator) trackPurgeTime(ctx context.Context, be *extensionsv1alpha1.BackupEntry, purgeTime time.Time) error {
+	value := purgeTime.UTC().Format(time.RFC3339)
+	if be.Annotations[AnnotationPurgeAfter] == value {
+		return nil
+	}
+
+	patch := client.MergeFrom(be.DeepCopy())
+	metav1.SetMetaDataAnnotation(&be.ObjectMeta, AnnotationPurgeAfter, value)
+	return a.client.Patch(ctx, be, patch)
+}
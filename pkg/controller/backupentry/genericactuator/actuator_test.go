@@ -16,17 +16,23 @@ package genericactuator_test
 
 import (
 	"context"
+	"time"
 
+	backupbucketgenericactuator "github.com/gardener/gardener-extensions/pkg/controller/backupbucket/genericactuator"
+	"github.com/gardener/gardener-extensions/pkg/controller/backupbucket/genericactuator/memory"
 	"github.com/gardener/gardener-extensions/pkg/controller/backupentry"
 	"github.com/gardener/gardener-extensions/pkg/controller/backupentry/genericactuator"
+	controllererror "github.com/gardener/gardener-extensions/pkg/controller/error"
 	mockgenericactuator "github.com/gardener/gardener-extensions/pkg/mock/gardener-extensions/controller/backupentry/genericactuator"
 
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -166,4 +172,85 @@ var _ = Describe("Actuator", func() {
 			})
 		})
 	})
+
+	Describe("#Delete", func() {
+		var (
+			ctx                 = context.TODO()
+			client              client.Client
+			backupEntryDelegate *mockgenericactuator.MockBackupEntryDelegate
+			deletedBE           *extensionsv1alpha1.BackupEntry
+			policy              = genericactuator.RetentionPolicy{MinimumRetention: 7 * 24 * time.Hour, DeletionGracePeriod: 24 * time.Hour}
+		)
+
+		BeforeEach(func() {
+			deletedBE = be.DeepCopy()
+			deletedBE.CreationTimestamp = metav1.NewTime(time.Now().Add(-30 * 24 * time.Hour))
+			deletedBE.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+			s := runtime.NewScheme()
+			Expect(corev1.AddToScheme(s)).To(Succeed())
+			Expect(extensionsv1alpha1.AddToScheme(s)).To(Succeed())
+			client = fakeclient.NewFakeClientWithScheme(s, deletedBE.DeepCopy())
+
+			backupEntryDelegate = mockgenericactuator.NewMockBackupEntryDelegate(ctrl)
+		})
+
+		newActuator := func(policy genericactuator.RetentionPolicy) backupentry.Actuator {
+			a := genericactuator.NewActuatorWithOptions(backupEntryDelegate, genericactuator.Options{RetentionPolicy: policy}, logger)
+			Expect(a.(inject.Client).InjectClient(client)).To(Succeed())
+			return a
+		}
+
+		It("should delete the backups immediately without retention policy", func() {
+			backupEntryDelegate.EXPECT().Delete(ctx, deletedBE)
+
+			Expect(newActuator(genericactuator.RetentionPolicy{}).Delete(ctx, deletedBE)).To(Succeed())
+		})
+
+		It("should retain the backups during the deletion grace period", func() {
+			err := newActuator(policy).Delete(ctx, deletedBE)
+			Expect(err).To(BeAssignableToTypeOf(&controllererror.PendingError{}))
+			Expect(err.(*controllererror.PendingError).RequeueAfter).To(BeNumerically("~", 24*time.Hour, time.Minute))
+
+			purgeAfter := deletedBE.DeletionTimestamp.Add(24 * time.Hour).UTC().Format(time.RFC3339)
+			actual := &extensionsv1alpha1.BackupEntry{}
+			Expect(client.Get(ctx, kutil.Key(deletedBE.Name), actual)).To(Succeed())
+			Expect(actual.Annotations).To(HaveKeyWithValue(genericactuator.AnnotationPurgeAfter, purgeAfter))
+		})
+
+		It("should retain the backups for the minimum retention", func() {
+			deletedBE.CreationTimestamp = metav1.NewTime(time.Now().Add(-5 * 24 * time.Hour))
+			deletedBE.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-2 * 24 * time.Hour)}
+
+			err := newActuator(policy).Delete(ctx, deletedBE)
+			Expect(err).To(BeAssignableToTypeOf(&controllererror.PendingError{}))
+			Expect(err.(*controllererror.PendingError).RequeueAfter).To(BeNumerically("~", 2*24*time.Hour, time.Minute))
+		})
+
+		It("should purge the backups after the retention", func() {
+			deletedBE.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-25 * time.Hour)}
+			backupEntryDelegate.EXPECT().Delete(ctx, deletedBE)
+
+			Expect(newActuator(policy).Delete(ctx, deletedBE)).To(Succeed())
+		})
+
+		It("should report the deletion of backups which are still immutable as pending", func() {
+			store := memory.NewObjectStore()
+			bb := &extensionsv1alpha1.BackupBucket{ObjectMeta: metav1.ObjectMeta{Name: bucketName}}
+			Expect(store.CreateBucket(ctx, bb, nil)).To(Succeed())
+			Expect(store.ApplyLifecyclePolicy(ctx, bb, nil, backupbucketgenericactuator.LifecyclePolicy{ImmutableFor: 14 * 24 * time.Hour})).To(Succeed())
+			Expect(store.PutObject(bucketName, memory.Object{Key: deletedBE.Name + "/v1/Backup-1583838000/Full-00000000-00000010-1583838000", LastModified: time.Now().Add(-10 * 24 * time.Hour)})).To(Succeed())
+
+			deletedBE.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-25 * time.Hour)}
+			a := genericactuator.NewActuatorWithOptions(store, genericactuator.Options{RetentionPolicy: policy}, logger)
+			Expect(a.(inject.Client).InjectClient(client)).To(Succeed())
+
+			err := a.Delete(ctx, deletedBE)
+			Expect(err).To(BeAssignableToTypeOf(&controllererror.PendingError{}))
+			Expect(err.(*controllererror.PendingError).RequeueAfter).To(BeNumerically("~", 4*24*time.Hour, time.Minute))
+
+			store.Now = func() time.Time { return time.Now().Add(5 * 24 * time.Hour) }
+			Expect(a.Delete(ctx, deletedBE)).To(Succeed())
+		})
+	})
 })
@@ -16,6 +16,7 @@ package genericactuator
 
 import (
 	"context"
+	"time"
 
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 )
@@ -24,12 +25,44 @@ const (
 	// EtcdBackupSecretName is the name of secret having credentials for etcd backups.
 	EtcdBackupSecretName string = "etcd-backup"
 
+	// AnnotationPurgeAfter is the annotation of a deleted BackupEntry containing the time after which its backups are
+	// purged in RFC3339 format.
+	AnnotationPurgeAfter = "backupentry.extensions.gardener.cloud/purge-after"
+
 	backupBucketName string = "bucketName"
 )
 
+// RetentionPolicy describes how long the backups of BackupEntries are retained.
+// If the backups are stored in buckets whose objects are immutable for some time (see the LifecyclePolicy of the
+// generic BackupBucket actuator), the backups might still be locked after the retention. The BackupEntryDelegate must
+// return a controllererror.PendingError for such backups, so that the deletion is reported as pending and requeued.
+type RetentionPolicy struct {
+	// MinimumRetention is the minimum duration the backups of an entry are retained after the entry was created.
+	MinimumRetention time.Duration
+	// DeletionGracePeriod is the duration the backups of an entry are retained after its deletion was requested.
+	// During this period the deletion is only a soft delete, i.e. the backups are still available.
+	DeletionGracePeriod time.Duration
+}
+
+// PurgeTime returns the time after which the backups of the given deleted BackupEntry may be purged. If the entry is
+// not deleted yet, the given time is assumed as deletion time.
+func (p RetentionPolicy) PurgeTime(be *extensionsv1alpha1.BackupEntry, now time.Time) time.Time {
+	deletionTime := now
+	if be.DeletionTimestamp != nil {
+		deletionTime = be.DeletionTimestamp.Time
+	}
+
+	purgeTime := deletionTime.Add(p.DeletionGracePeriod)
+	if retainedUntil := be.CreationTimestamp.Add(p.MinimumRetention); retainedUntil.After(purgeTime) {
+		purgeTime = retainedUntil
+	}
+	return purgeTime
+}
+
 // BackupEntryDelegate preforms provider specific operation with BackupBucket resources.
 type BackupEntryDelegate interface {
-	// Delete deletes the BackupBucket.
+	// Delete deletes the BackupBucket. If backups cannot be deleted yet as they are still immutable, it returns a
+	// controllererror.PendingError.
 	Delete(context.Context, *extensionsv1alpha1.BackupEntry) error
 	// GetETCDSecretData returns the updated secret data as per provider requirement.
 	GetETCDSecretData(context.Context, *extensionsv1alpha1.BackupEntry, map[string][]byte) (map[string][]byte, error)
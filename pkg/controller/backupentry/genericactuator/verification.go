@@ -0,0 +1,131 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genericactuator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardencorev1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// ConditionTypeBackupsAvailable is the type of the condition of a BackupEntry reporting whether recent snapshots
+	// exist for it.
+	ConditionTypeBackupsAvailable gardencorev1beta1.ConditionType = "BackupsAvailable"
+
+	// DefaultMaxSnapshotAge is the default age after which the newest snapshot of a BackupEntry is considered outdated.
+	DefaultMaxSnapshotAge = 24 * time.Hour
+
+	// backupsAvailableResyncsPerMaxSnapshotAge is the number of times the ConditionTypeBackupsAvailable condition is
+	// updated within the maximum snapshot age.
+	backupsAvailableResyncsPerMaxSnapshotAge = 4
+)
+
+// SnapshotKind is the kind of a snapshot.
+type SnapshotKind string
+
+const (
+	// SnapshotKindFull is the kind of full snapshots.
+	SnapshotKindFull SnapshotKind = "Full"
+	// SnapshotKindDelta is the kind of delta snapshots.
+	SnapshotKindDelta SnapshotKind = "Delta"
+)
+
+// Snapshot is an etcd snapshot stored for a BackupEntry.
+type Snapshot struct {
+	// Kind is the kind of the snapshot.
+	Kind SnapshotKind
+	// CreationTime is the time the snapshot was taken.
+	CreationTime time.Time
+	// Size is the size of the snapshot in bytes.
+	Size int64
+}
+
+// BackupEntryVerifier can optionally be implemented by a BackupEntryDelegate to verify that usable backups exist.
+// The result is reported in the ConditionTypeBackupsAvailable condition of the BackupEntry. As the BackupEntryStatus
+// has no fields for them, the times and sizes of the latest full and delta snapshots are only contained in the message
+// of this condition.
+type BackupEntryVerifier interface {
+	// ListSnapshots returns the snapshots stored under the prefix of the given BackupEntry in its bucket.
+	ListSnapshots(context.Context, *extensionsv1alpha1.BackupEntry, map[string][]byte) ([]Snapshot, error)
+}
+
+// BackupsAvailableCondition returns the given condition updated according to the given snapshots of a BackupEntry
+// created at the given time. The condition is false if there are no snapshots or if the newest one is older than the
+// given maximum age. As the first snapshot is only taken some time after the BackupEntry has been created, missing
+// snapshots are reported with an unknown status while the BackupEntry is younger than the maximum age.
+func BackupsAvailableCondition(condition gardencorev1beta1.Condition, snapshots []Snapshot, creationTime time.Time, maxAge time.Duration, now time.Time) gardencorev1beta1.Condition {
+	var latestFull, latestDelta *Snapshot
+	for i, snapshot := range snapshots {
+		switch {
+		case snapshot.Kind == SnapshotKindFull && (latestFull == nil || snapshot.CreationTime.After(latestFull.CreationTime)):
+			latestFull = &snapshots[i]
+		case snapshot.Kind == SnapshotKindDelta && (latestDelta == nil || snapshot.CreationTime.After(latestDelta.CreationTime)):
+			latestDelta = &snapshots[i]
+		}
+	}
+
+	if latestFull == nil && latestDelta == nil {
+		if now.Sub(creationTime) < maxAge {
+			return gardencorev1beta1helper.UpdatedCondition(condition, gardencorev1beta1.ConditionUnknown, "SnapshotsPending", fmt.Sprintf("No snapshots found yet, the first snapshot is expected within %s after the creation.", maxAge))
+		}
+		return gardencorev1beta1helper.UpdatedCondition(condition, gardencorev1beta1.ConditionFalse, "NoSnapshots", "No snapshots found.")
+	}
+
+	message := fmt.Sprintf("Latest full snapshot: %s, latest delta snapshot: %s.", describeSnapshot(latestFull), describeSnapshot(latestDelta))
+
+	newest := latestFull
+	if newest == nil || (latestDelta != nil && latestDelta.CreationTime.After(newest.CreationTime)) {
+		newest = latestDelta
+	}
+	if age := now.Sub(newest.CreationTime); age > maxAge {
+		return gardencorev1beta1helper.UpdatedCondition(condition, gardencorev1beta1.ConditionFalse, "SnapshotsOutdated", fmt.Sprintf("Newest snapshot is older than %s. %s", maxAge, message))
+	}
+	return gardencorev1beta1helper.UpdatedCondition(condition, gardencorev1beta1.ConditionTrue, "SnapshotsAvailable", message)
+}
+
+func describeSnapshot(snapshot *Snapshot) string {
+	if snapshot == nil {
+		return "none"
+	}
+	return fmt.Sprintf("%s (%d bytes)", snapshot.CreationTime.UTC().Format(time.RFC3339), snapshot.Size)
+}
+
+// verifyBackups updates the ConditionTypeBackupsAvailable condition of the given BackupEntry with the snapshots listed
+// by the given verifier. As backups are taken continuously, the BackupEntry is reconciled periodically (see ResyncPeriod)
+// to keep the condition up to date.
+func (a *actuator) verifyBackups(ctx context.Context, verifier BackupEntryVerifier, be *extensionsv1alpha1.BackupEntry, secretData map[string][]byte) error {
+	condition := gardencorev1beta1helper.GetOrInitCondition(be.Status.Conditions, ConditionTypeBackupsAvailable)
+
+	snapshots, err := verifier.ListSnapshots(ctx, be, secretData)
+	if err != nil {
+		a.logger.Error(err, "failed to list snapshots", "backupentry", be.Name)
+		condition = gardencorev1beta1helper.UpdatedConditionUnknownError(condition, err)
+	} else {
+		condition = BackupsAvailableCondition(condition, snapshots, be.CreationTimestamp.Time, a.maxSnapshotAge, time.Now())
+	}
+
+	return extensionscontroller.TryUpdateStatus(ctx, retry.DefaultBackoff, a.client, be, func() error {
+		be.Status.Conditions = gardencorev1beta1helper.MergeConditions(be.Status.Conditions, condition)
+		return nil
+	})
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genericactuator_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/backupbucket/genericactuator/memory"
+	"github.com/gardener/gardener-extensions/pkg/controller/backupentry"
+	"github.com/gardener/gardener-extensions/pkg/controller/backupentry/genericactuator"
+	mockgenericactuator "github.com/gardener/gardener-extensions/pkg/mock/gardener-extensions/controller/backupentry/genericactuator"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardencorev1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+)
+
+var _ = Describe("Verification", func() {
+	var (
+		now       = time.Date(2020, 3, 10, 12, 0, 0, 0, time.UTC)
+		created   = now.Add(-30 * 24 * time.Hour)
+		condition = gardencorev1beta1helper.InitCondition(genericactuator.ConditionTypeBackupsAvailable)
+		full      = genericactuator.Snapshot{Kind: genericactuator.SnapshotKindFull, CreationTime: now.Add(-10 * time.Hour), Size: 1024}
+		oldFull   = genericactuator.Snapshot{Kind: genericactuator.SnapshotKindFull, CreationTime: now.Add(-34 * time.Hour), Size: 512}
+		delta     = genericactuator.Snapshot{Kind: genericactuator.SnapshotKindDelta, CreationTime: now.Add(-time.Hour), Size: 16}
+	)
+
+	DescribeTable("#BackupsAvailableCondition",
+		func(snapshots []genericactuator.Snapshot, creationTime time.Time, status gardencorev1beta1.ConditionStatus, reason, message string) {
+			actual := genericactuator.BackupsAvailableCondition(condition, snapshots, creationTime, genericactuator.DefaultMaxSnapshotAge, now)
+			Expect(actual.Status).To(Equal(status))
+			Expect(actual.Reason).To(Equal(reason))
+			Expect(actual.Message).To(Equal(message))
+		},
+		Entry("no snapshots", nil, created, gardencorev1beta1.ConditionFalse, "NoSnapshots", "No snapshots found."),
+		Entry("no snapshots of a new entry", nil, now.Add(-time.Hour), gardencorev1beta1.ConditionUnknown, "SnapshotsPending",
+			"No snapshots found yet, the first snapshot is expected within 24h0m0s after the creation."),
+		Entry("recent snapshots", []genericactuator.Snapshot{oldFull, full, delta}, created, gardencorev1beta1.ConditionTrue, "SnapshotsAvailable",
+			"Latest full snapshot: 2020-03-10T02:00:00Z (1024 bytes), latest delta snapshot: 2020-03-10T11:00:00Z (16 bytes)."),
+		Entry("recent delta snapshot only", []genericactuator.Snapshot{delta}, created, gardencorev1beta1.ConditionTrue, "SnapshotsAvailable",
+			"Latest full snapshot: none, latest delta snapshot: 2020-03-10T11:00:00Z (16 bytes)."),
+		Entry("outdated snapshots", []genericactuator.Snapshot{oldFull}, created, gardencorev1beta1.ConditionFalse, "SnapshotsOutdated",
+			"Newest snapshot is older than 24h0m0s. Latest full snapshot: 2020-03-09T02:00:00Z (512 bytes), latest delta snapshot: none."),
+	)
+
+	Describe("#ResyncPeriod", func() {
+		It("should resync verified BackupEntries within the maximum snapshot age", func() {
+			a := genericactuator.NewActuatorWithOptions(memory.NewObjectStore(), genericactuator.Options{MaxSnapshotAge: 8 * time.Hour}, log.Log.WithName("test"))
+			Expect(a.(backupentry.ResyncActuator).ResyncPeriod()).To(Equal(2 * time.Hour))
+		})
+
+		It("should not resync BackupEntries that are not verified", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			defer ctrl.Finish()
+
+			a := genericactuator.NewActuator(mockgenericactuator.NewMockBackupEntryDelegate(ctrl), log.Log.WithName("test"))
+			Expect(a.(backupentry.ResyncActuator).ResyncPeriod()).To(BeZero())
+		})
+	})
+
+	Describe("#Reconcile", func() {
+		var (
+			ctx   = context.TODO()
+			c     client.Client
+			store *memory.ObjectStore
+			be    *extensionsv1alpha1.BackupEntry
+		)
+
+		BeforeEach(func() {
+			be = &extensionsv1alpha1.BackupEntry{
+				ObjectMeta: metav1.ObjectMeta{Name: shootTechnicalID + "--" + shootUID},
+				Spec: extensionsv1alpha1.BackupEntrySpec{
+					BucketName: bucketName,
+					SecretRef:  corev1.SecretReference{Name: providerSecretName, Namespace: providerSecretNamespace},
+				},
+			}
+
+			s := runtime.NewScheme()
+			Expect(corev1.AddToScheme(s)).To(Succeed())
+			Expect(extensionsv1alpha1.AddToScheme(s)).To(Succeed())
+			c = fakeclient.NewFakeClientWithScheme(s,
+				be.DeepCopy(),
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: providerSecretName, Namespace: providerSecretNamespace}},
+			)
+
+			store = memory.NewObjectStore()
+			Expect(store.CreateBucket(ctx, &extensionsv1alpha1.BackupBucket{ObjectMeta: metav1.ObjectMeta{Name: bucketName}}, nil)).To(Succeed())
+		})
+
+		reconcile := func() gardencorev1beta1.Condition {
+			a := genericactuator.NewActuator(store, log.Log.WithName("test"))
+			Expect(a.(inject.Client).InjectClient(c)).To(Succeed())
+			Expect(a.Reconcile(ctx, be)).To(Succeed())
+
+			actual := &extensionsv1alpha1.BackupEntry{}
+			Expect(c.Get(ctx, kutil.Key(be.Name), actual)).To(Succeed())
+			condition := gardencorev1beta1helper.GetCondition(actual.Status.Conditions, genericactuator.ConditionTypeBackupsAvailable)
+			Expect(condition).NotTo(BeNil())
+			return *condition
+		}
+
+		It("should report available snapshots", func() {
+			Expect(store.PutObject(bucketName, memory.Object{Key: be.Name + "/v1/Backup-1583838000/Full-00000000-00000010-1583838000", Data: []byte("full"), LastModified: time.Now()})).To(Succeed())
+			Expect(store.PutObject(bucketName, memory.Object{Key: be.Name + "/v1/Backup-1583838000/Incr-00000011-00000020-1583841600", Data: []byte("delta"), LastModified: time.Now()})).To(Succeed())
+
+			condition := reconcile()
+			Expect(condition.Status).To(Equal(gardencorev1beta1.ConditionTrue))
+			Expect(condition.Message).To(ContainSubstring("(4 bytes)"))
+			Expect(condition.Message).To(ContainSubstring("(5 bytes)"))
+		})
+
+		It("should report missing snapshots of other entries", func() {
+			Expect(store.PutObject(bucketName, memory.Object{Key: "other/v1/Backup-1583838000/Full-00000000-00000010-1583838000", LastModified: time.Now()})).To(Succeed())
+
+			condition := reconcile()
+			Expect(condition.Status).To(Equal(gardencorev1beta1.ConditionFalse))
+			Expect(condition.Reason).To(Equal("NoSnapshots"))
+		})
+	})
+})
@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	controllererror "github.com/gardener/gardener-extensions/pkg/controller/error"
 	"github.com/gardener/gardener-extensions/pkg/util"
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	gardencorev1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
@@ -129,6 +130,11 @@ func (r *reconciler) reconcile(ctx context.Context, be *extensionsv1alpha1.Backu
 		return reconcile.Result{}, err
 	}
 
+	if resyncActuator, ok := r.actuator.(ResyncActuator); ok {
+		if resync := resyncActuator.ResyncPeriod(); resync > 0 {
+			return reconcile.Result{RequeueAfter: resync}, nil
+		}
+	}
 	return reconcile.Result{}, nil
 }
 
@@ -151,6 +157,14 @@ func (r *reconciler) delete(ctx context.Context, be *extensionsv1alpha1.BackupEn
 	r.logger.Info("Starting the deletion of backupentry", "backupentry", be.Name)
 	r.recorder.Event(be, corev1.EventTypeNormal, EventBackupEntryDeletion, "Deleting the backupentry")
 	if err := r.actuator.Delete(r.ctx, be); err != nil {
+		if pending, ok := err.(*controllererror.PendingError); ok {
+			r.logger.Info("Deletion of backupentry is pending", "backupentry", be.Name, "reason", pending.Description, "requeueAfter", pending.RequeueAfter)
+			if err := r.updateStatusPending(ctx, be, operationType, pending.Description); err != nil {
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{RequeueAfter: pending.RequeueAfter}, nil
+		}
+
 		msg := "Error deleting backupentry"
 		r.recorder.Eventf(be, corev1.EventTypeWarning, EventBackupEntryDeletion, "%s: %+v", msg, err)
 		_ = r.updateStatusError(ctx, extensionscontroller.ReconcileErrCauseOrErr(err), be, operationType, msg)
@@ -191,6 +205,15 @@ func (r *reconciler) updateStatusProcessing(ctx context.Context, be *extensionsv
 	})
 }
 
+func (r *reconciler) updateStatusPending(ctx context.Context, be *extensionsv1alpha1.BackupEntry, lastOperationType gardencorev1beta1.LastOperationType, description string) error {
+	return extensionscontroller.TryUpdateStatus(ctx, retry.DefaultBackoff, r.client, be, func() error {
+		be.Status.ObservedGeneration = be.Generation
+		be.Status.LastOperation = extensionscontroller.LastOperation(lastOperationType, gardencorev1beta1.LastOperationStatePending, 1, description)
+		be.Status.LastError = nil
+		return nil
+	})
+}
+
 func (r *reconciler) updateStatusError(ctx context.Context, err error, be *extensionsv1alpha1.BackupEntry, lastOperationType gardencorev1beta1.LastOperationType, description string) error {
 	return extensionscontroller.TryUpdateStatus(ctx, retry.DefaultBackoff, r.client, be, func() error {
 		be.Status.ObservedGeneration = be.Generation
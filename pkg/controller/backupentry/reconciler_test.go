@@ -0,0 +1,150 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backupentry_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/gardener/gardener-extensions/pkg/controller/backupentry"
+	controllererror "github.com/gardener/gardener-extensions/pkg/controller/error"
+	mockmanager "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/manager"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+)
+
+type fakeActuator struct {
+	deleteErr    error
+	resyncPeriod time.Duration
+}
+
+func (a *fakeActuator) ResyncPeriod() time.Duration {
+	return a.resyncPeriod
+}
+
+func (a *fakeActuator) Reconcile(context.Context, *extensionsv1alpha1.BackupEntry) error {
+	return nil
+}
+
+func (a *fakeActuator) Delete(context.Context, *extensionsv1alpha1.BackupEntry) error {
+	return a.deleteErr
+}
+
+var _ = Describe("Reconciler", func() {
+	var (
+		ctrl     *gomock.Controller
+		c        client.Client
+		recorder *record.FakeRecorder
+		stopCh   chan struct{}
+
+		be = &extensionsv1alpha1.BackupEntry{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "shoot--foo--bar--1234",
+				Finalizers:        []string{FinalizerName},
+				DeletionTimestamp: &metav1.Time{Time: time.Now()},
+			},
+			Spec: extensionsv1alpha1.BackupEntrySpec{
+				SecretRef: corev1.SecretReference{Namespace: "garden", Name: "secret"},
+			},
+		}
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		stopCh = make(chan struct{})
+
+		s := runtime.NewScheme()
+		Expect(corev1.AddToScheme(s)).To(Succeed())
+		Expect(extensionsv1alpha1.AddToScheme(s)).To(Succeed())
+		c = fakeclient.NewFakeClientWithScheme(s,
+			be.DeepCopy(),
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "garden", Name: "secret"}},
+		)
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	AfterEach(func() {
+		close(stopCh)
+		ctrl.Finish()
+	})
+
+	newReconciler := func(actuator Actuator) reconcile.Reconciler {
+		mgr := mockmanager.NewMockManager(ctrl)
+		mgr.EXPECT().GetEventRecorderFor(ControllerName).Return(recorder)
+		r := NewReconciler(mgr, actuator)
+
+		var setFields inject.Func
+		setFields = func(i interface{}) error {
+			if _, err := inject.ClientInto(c, i); err != nil {
+				return err
+			}
+			if _, err := inject.StopChannelInto(stopCh, i); err != nil {
+				return err
+			}
+			_, err := inject.InjectorInto(setFields, i)
+			return err
+		}
+		Expect(setFields(r)).To(Succeed())
+		return r
+	}
+
+	It("should requeue a reconciled BackupEntry after the resync period", func() {
+		reconciled := be.DeepCopy()
+		reconciled.Name = "shoot--foo--baz--1234"
+		reconciled.DeletionTimestamp = nil
+		Expect(c.Create(context.TODO(), reconciled)).To(Succeed())
+		r := newReconciler(&fakeActuator{resyncPeriod: time.Hour})
+
+		result, err := r.Reconcile(reconcile.Request{NamespacedName: client.ObjectKey{Name: reconciled.Name}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{RequeueAfter: time.Hour}))
+
+		actual := &extensionsv1alpha1.BackupEntry{}
+		Expect(c.Get(context.TODO(), client.ObjectKey{Name: reconciled.Name}, actual)).To(Succeed())
+		Expect(actual.Status.LastOperation.State).To(Equal(gardencorev1beta1.LastOperationStateSucceeded))
+	})
+
+	It("should report a pending deletion without an error", func() {
+		r := newReconciler(&fakeActuator{deleteErr: &controllererror.PendingError{Description: "Backups are retained", RequeueAfter: time.Hour}})
+
+		result, err := r.Reconcile(reconcile.Request{NamespacedName: client.ObjectKey{Name: be.Name}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{RequeueAfter: time.Hour}))
+
+		actual := &extensionsv1alpha1.BackupEntry{}
+		Expect(c.Get(context.TODO(), client.ObjectKey{Name: be.Name}, actual)).To(Succeed())
+		Expect(actual.Status.LastOperation.State).To(Equal(gardencorev1beta1.LastOperationStatePending))
+		Expect(actual.Status.LastOperation.Description).To(Equal("Backups are retained"))
+		Expect(actual.Status.LastError).To(BeNil())
+		Expect(actual.Finalizers).To(ConsistOf(FinalizerName))
+
+		close(recorder.Events)
+		for event := range recorder.Events {
+			Expect(event).NotTo(HavePrefix(corev1.EventTypeWarning))
+		}
+	})
+})
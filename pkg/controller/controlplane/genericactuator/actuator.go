@@ -23,6 +23,7 @@ import (
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
 	"github.com/gardener/gardener-extensions/pkg/controller/controlplane"
 	"github.com/gardener/gardener-extensions/pkg/util"
+	extensionswebhook "github.com/gardener/gardener-extensions/pkg/webhook"
 	extensionswebhookshoot "github.com/gardener/gardener-extensions/pkg/webhook/shoot"
 
 	"github.com/gardener/gardener-resource-manager/pkg/manager"
@@ -37,6 +38,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer/json"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -69,7 +71,7 @@ func NewActuator(
 	chartRendererFactory extensionscontroller.ChartRendererFactory,
 	imageVector imagevector.ImageVector,
 	configName string,
-	shootWebhooks []admissionregistrationv1beta1.MutatingWebhook,
+	shootWebhooks *extensionswebhook.Configs,
 	webhookServerPort int,
 	logger logr.Logger,
 ) controlplane.Actuator {
@@ -106,7 +108,7 @@ type actuator struct {
 	chartRendererFactory      extensionscontroller.ChartRendererFactory
 	imageVector               imagevector.ImageVector
 	configName                string
-	shootWebhooks             []admissionregistrationv1beta1.MutatingWebhook
+	shootWebhooks             *extensionswebhook.Configs
 	webhookServerPort         int
 
 	clientset         kubernetes.Interface
@@ -157,7 +159,7 @@ const (
 	// StorageClassesChartResourceName is the name of the managed resource for the extension control plane storageclasses
 	StorageClassesChartResourceName = "extension-controlplane-storageclasses"
 	// ShootWebhooksResourceName is the name of the managed resource for the extension control plane webhooks
-	ShootWebhooksResourceName = "extension-controlplane-shoot-webhooks"
+	ShootWebhooksResourceName = extensionswebhook.ShootWebhooksResourceName
 )
 
 // Reconcile reconciles the given controlplane and cluster, creating or updating the additional Shoot
@@ -218,13 +220,13 @@ func (a *actuator) reconcileControlPlane(
 	cluster *extensionscontroller.Cluster,
 ) (bool, error) {
 
-	if len(a.shootWebhooks) > 0 {
+	if !a.shootWebhooks.IsEmpty() {
 		// Deploy shoot webhook configurations
 		if err := extensionswebhookshoot.EnsureNetworkPolicy(ctx, a.client, cp.Namespace, a.providerName, a.webhookServerPort); err != nil {
 			return false, errors.Wrapf(err, "could not create or update network policy for shoot webhooks in namespace '%s'", cp.Namespace)
 		}
 
-		webhookConfiguration, err := marshalWebhooks(a.shootWebhooks, a.providerName)
+		webhookConfigurations, err := marshalWebhooks(a.shootWebhooks, a.providerName)
 		if err != nil {
 			return false, err
 		}
@@ -232,7 +234,7 @@ func (a *actuator) reconcileControlPlane(
 		if err := manager.
 			NewSecret(a.client).
 			WithNamespacedName(cp.Namespace, ShootWebhooksResourceName).
-			WithKeyValues(map[string][]byte{"mutatingwebhookconfiguration.yaml": webhookConfiguration}).
+			WithKeyValues(webhookConfigurations).
 			Reconcile(ctx); err != nil {
 			return false, errors.Wrapf(err, "could not create or update secret '%s/%s' of managed resource containing shoot webhooks", cp.Namespace, ShootWebhooksResourceName)
 		}
@@ -431,7 +433,7 @@ func (a *actuator) deleteControlPlane(
 		return errors.Wrapf(err, "could not delete secrets for controlplane '%s'", util.ObjectName(cp))
 	}
 
-	if len(a.shootWebhooks) > 0 {
+	if !a.shootWebhooks.IsEmpty() {
 		networkPolicy := extensionswebhookshoot.GetNetworkPolicyMeta(cp.Namespace, a.providerName)
 		if err := a.client.Delete(ctx, networkPolicy); client.IgnoreNotFound(err) != nil {
 			return errors.Wrapf(err, "could not delete network policy for shoot webhooks in namespace '%s'", cp.Namespace)
@@ -483,13 +485,20 @@ func (a *actuator) computeChecksums(
 	return controlplane.ComputeChecksums(csSecrets, csConfigMaps), nil
 }
 
-func marshalWebhooks(webhooks []admissionregistrationv1beta1.MutatingWebhook, name string) ([]byte, error) {
+// marshalWebhooks marshals the given webhooks into a MutatingWebhookConfiguration and/or a ValidatingWebhookConfiguration
+// and returns them keyed by the file names used in the managed resource secret.
+func marshalWebhooks(webhooks *extensionswebhook.Configs, name string) (map[string][]byte, error) {
 	var (
-		buf     = new(bytes.Buffer)
 		encoder = json.NewYAMLSerializer(json.DefaultMetaFactory, nil, nil)
+		objects = map[string]runtime.Object{}
+		data    = map[string][]byte{}
 
-		apiVersion, kind             = admissionregistrationv1beta1.SchemeGroupVersion.WithKind("MutatingWebhookConfiguration").ToAPIVersionAndKind()
-		mutatingWebhookConfiguration = admissionregistrationv1beta1.MutatingWebhookConfiguration{
+		mutatingWebhooks, validatingWebhooks = webhooks.Get()
+	)
+
+	if len(mutatingWebhooks) > 0 {
+		apiVersion, kind := admissionregistrationv1beta1.SchemeGroupVersion.WithKind("MutatingWebhookConfiguration").ToAPIVersionAndKind()
+		objects["mutatingwebhookconfiguration.yaml"] = &admissionregistrationv1beta1.MutatingWebhookConfiguration{
 			TypeMeta: metav1.TypeMeta{
 				APIVersion: apiVersion,
 				Kind:       kind,
@@ -497,13 +506,31 @@ func marshalWebhooks(webhooks []admissionregistrationv1beta1.MutatingWebhook, na
 			ObjectMeta: metav1.ObjectMeta{
 				Name: fmt.Sprintf("gardener-extension-%s-shoot", name),
 			},
-			Webhooks: webhooks,
+			Webhooks: mutatingWebhooks,
 		}
-	)
+	}
 
-	if err := encoder.Encode(&mutatingWebhookConfiguration, buf); err != nil {
-		return nil, err
+	if len(validatingWebhooks) > 0 {
+		apiVersion, kind := admissionregistrationv1beta1.SchemeGroupVersion.WithKind("ValidatingWebhookConfiguration").ToAPIVersionAndKind()
+		objects["validatingwebhookconfiguration.yaml"] = &admissionregistrationv1beta1.ValidatingWebhookConfiguration{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: apiVersion,
+				Kind:       kind,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: fmt.Sprintf("gardener-extension-%s-shoot", name),
+			},
+			Webhooks: validatingWebhooks,
+		}
+	}
+
+	for key, obj := range objects {
+		buf := new(bytes.Buffer)
+		if err := encoder.Encode(obj, buf); err != nil {
+			return nil, err
+		}
+		data[key] = buf.Bytes()
 	}
 
-	return buf.Bytes(), nil
+	return data, nil
 }
@@ -26,6 +26,7 @@ import (
 	mockchartrenderer "github.com/gardener/gardener-extensions/pkg/mock/gardener/chartrenderer"
 	mockkubernetes "github.com/gardener/gardener-extensions/pkg/mock/gardener/client/kubernetes"
 	"github.com/gardener/gardener-extensions/pkg/util"
+	extensionswebhook "github.com/gardener/gardener-extensions/pkg/webhook"
 	extensionswebhookshoot "github.com/gardener/gardener-extensions/pkg/webhook/shoot"
 
 	resourcemanagerv1alpha1 "github.com/gardener/gardener-resource-manager/pkg/apis/resources/v1alpha1"
@@ -279,20 +280,20 @@ var _ = Describe("Actuator", func() {
 	})
 
 	DescribeTable("#Reconcile",
-		func(configName string, checksums map[string]string, webhooks []admissionregistrationv1beta1.MutatingWebhook) {
+		func(configName string, checksums map[string]string, webhooks *extensionswebhook.Configs) {
 			ctx := context.TODO()
 
 			// Create mock client
 			client := mockclient.NewMockClient(ctrl)
 
-			if len(webhooks) > 0 {
+			if !webhooks.IsEmpty() {
 				client.EXPECT().Get(ctx, resourceKeyShootWebhooksNetworkPolicy, gomock.AssignableToTypeOf(&networkingv1.NetworkPolicy{})).Return(errNotFound)
 				client.EXPECT().Create(ctx, createdNetworkPolicyForShootWebhooks).Return(nil)
 
 				data, _ := marshalWebhooks(webhooks, providerName)
 				createdMRSecretForShootWebhooks := &corev1.Secret{
 					ObjectMeta: metav1.ObjectMeta{Name: ShootWebhooksResourceName, Namespace: namespace},
-					Data:       map[string][]byte{"mutatingwebhookconfiguration.yaml": data},
+					Data:       data,
 					Type:       corev1.SecretTypeOpaque,
 				}
 				client.EXPECT().Get(ctx, resourceKeyShootWebhooks, gomock.AssignableToTypeOf(&corev1.Secret{})).Return(errNotFound)
@@ -363,13 +364,14 @@ var _ = Describe("Actuator", func() {
 			Expect(requeue).To(Equal(false))
 			Expect(err).NotTo(HaveOccurred())
 		},
-		Entry("should deploy secrets and apply charts with correct parameters", cloudProviderConfigName, checksums, []admissionregistrationv1beta1.MutatingWebhook{{}}),
-		Entry("should deploy secrets and apply charts with correct parameters (no config)", "", checksumsNoConfig, []admissionregistrationv1beta1.MutatingWebhook{{}}),
+		Entry("should deploy secrets and apply charts with correct parameters", cloudProviderConfigName, checksums, &extensionswebhook.Configs{MutatingWebhooks: []admissionregistrationv1beta1.MutatingWebhook{{}}}),
+		Entry("should deploy secrets and apply charts with correct parameters (validating webhooks)", cloudProviderConfigName, checksums, &extensionswebhook.Configs{MutatingWebhooks: []admissionregistrationv1beta1.MutatingWebhook{{}}, ValidatingWebhooks: []admissionregistrationv1beta1.ValidatingWebhook{{}}}),
+		Entry("should deploy secrets and apply charts with correct parameters (no config)", "", checksumsNoConfig, &extensionswebhook.Configs{MutatingWebhooks: []admissionregistrationv1beta1.MutatingWebhook{{}}}),
 		Entry("should deploy secrets and apply charts with correct parameters (no webhook)", cloudProviderConfigName, checksums, nil),
 	)
 
 	DescribeTable("#Delete",
-		func(configName string, webhooks []admissionregistrationv1beta1.MutatingWebhook) {
+		func(configName string, webhooks *extensionswebhook.Configs) {
 			ctx := context.TODO()
 
 			// Create mock clients
@@ -396,7 +398,7 @@ var _ = Describe("Actuator", func() {
 			ccmChart := mockutil.NewMockChart(ctrl)
 			ccmChart.EXPECT().Delete(ctx, client, namespace).Return(nil)
 
-			if len(webhooks) > 0 {
+			if !webhooks.IsEmpty() {
 				client.EXPECT().Delete(ctx, deletedNetworkPolicyForShootWebhooks).Return(nil)
 				client.EXPECT().Delete(ctx, deletedMRForShootWebhooks).Return(nil)
 				client.EXPECT().Delete(ctx, deletedMRSecretForShootWebhooks).Return(nil)
@@ -412,9 +414,9 @@ var _ = Describe("Actuator", func() {
 			err = a.Delete(ctx, cp, cluster)
 			Expect(err).NotTo(HaveOccurred())
 		},
-		Entry("should delete secrets and charts", cloudProviderConfigName, []admissionregistrationv1beta1.MutatingWebhook{{}}),
-		Entry("should delete secrets and charts (no config)", "", []admissionregistrationv1beta1.MutatingWebhook{{}}),
-		Entry("should delete secrets and charts (no webhook)", cloudProviderConfigName, []admissionregistrationv1beta1.MutatingWebhook{{}}),
+		Entry("should delete secrets and charts", cloudProviderConfigName, &extensionswebhook.Configs{MutatingWebhooks: []admissionregistrationv1beta1.MutatingWebhook{{}}}),
+		Entry("should delete secrets and charts (no config)", "", &extensionswebhook.Configs{MutatingWebhooks: []admissionregistrationv1beta1.MutatingWebhook{{}}}),
+		Entry("should delete secrets and charts (no webhook)", cloudProviderConfigName, &extensionswebhook.Configs{MutatingWebhooks: []admissionregistrationv1beta1.MutatingWebhook{{}}}),
 	)
 
 	DescribeTable("#ReconcileExposure",
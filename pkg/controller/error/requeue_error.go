@@ -35,3 +35,17 @@ func (e *RequeueAfterError) Error() string {
 
 	return fmt.Sprintf("requeue in %s due to %+v", e.RequeueAfter, e.Cause)
 }
+
+// PendingError is an error that indicates that an actuator cannot complete an operation yet, e.g. because it has to
+// wait for some time to pass, but that the operation did not fail. Reconcilers report the operation as pending instead
+// of failed and requeue it after RequeueAfter has passed.
+type PendingError struct {
+	// Description describes why the operation is pending.
+	Description string
+	// RequeueAfter is the duration after which the request should be enqueued again.
+	RequeueAfter time.Duration
+}
+
+func (e *PendingError) Error() string {
+	return fmt.Sprintf("%s, requeue in %s", e.Description, e.RequeueAfter)
+}
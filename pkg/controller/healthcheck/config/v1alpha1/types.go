@@ -22,4 +22,78 @@ type HealthCheckConfig struct {
 	// already running on them).
 	// defaults to 30 sec
 	SyncPeriod metav1.Duration `json:"syncPeriod"`
+	// Policies are additional health checks declared by the operator. Each policy is mapped to a condition type and
+	// executed along with the health checks registered in code.
+	Policies []HealthCheckPolicy `json:"policies,omitempty"`
+	// Thresholds configures how many consecutive check runs are required before a condition changes its status.
+	// If not set, a condition changes its status with every check run.
+	Thresholds *HealthCheckThresholds `json:"thresholds,omitempty"`
+	// CheckTimeout is the maximum duration of a single health check. Checks exceeding it are reported as failed.
+	// Defaults to 30 sec.
+	CheckTimeout *metav1.Duration `json:"checkTimeout,omitempty"`
+	// MaxConcurrentChecks is the maximum number of health checks that are executed concurrently by a health check
+	// controller. Defaults to 20.
+	MaxConcurrentChecks *int32 `json:"maxConcurrentChecks,omitempty"`
+}
+
+// HealthCheckThresholds configures the hysteresis of the health check conditions.
+type HealthCheckThresholds struct {
+	// FailureThreshold is the number of consecutive unsuccessful check runs after which a condition is set to 'False'.
+	// Defaults to 1.
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+	// SuccessThreshold is the number of consecutive successful check runs after which a condition is set to 'True'.
+	// Defaults to 1.
+	SuccessThreshold int32 `json:"successThreshold,omitempty"`
+	// ProgressingGracePeriod is the maximum duration a condition stays 'Progressing' after the first unsuccessful check
+	// run, even if the failure threshold is not yet reached. If not set, only the failure threshold is considered.
+	ProgressingGracePeriod *metav1.Duration `json:"progressingGracePeriod,omitempty"`
+}
+
+// ClusterType is the cluster in which a health check is executed.
+type ClusterType string
+
+const (
+	// ClusterTypeSeed is the seed cluster.
+	ClusterTypeSeed ClusterType = "Seed"
+	// ClusterTypeShoot is the shoot cluster.
+	ClusterTypeShoot ClusterType = "Shoot"
+)
+
+// HealthCheckPolicy declares a health check for an extension resource. Exactly one of the checks must be set.
+type HealthCheckPolicy struct {
+	// Extension is the kind of the extension resource the health check is registered for, e.g. 'ControlPlane' or 'Worker'.
+	Extension string `json:"extension"`
+	// ConditionType is the condition type the health check contributes to, e.g. 'ControlPlaneHealthy'.
+	ConditionType string `json:"conditionType"`
+	// Deployment checks a deployment.
+	Deployment *DeploymentHealthCheckPolicy `json:"deployment,omitempty"`
+	// DaemonSet checks a daemon set.
+	DaemonSet *DaemonSetHealthCheckPolicy `json:"daemonSet,omitempty"`
+	// ManagedResource checks a managed resource in the seed cluster.
+	ManagedResource *ManagedResourceHealthCheckPolicy `json:"managedResource,omitempty"`
+}
+
+// DeploymentHealthCheckPolicy declares a health check for a deployment.
+type DeploymentHealthCheckPolicy struct {
+	// Name is the name of the deployment in the namespace of the extension resource.
+	Name string `json:"name"`
+	// Cluster is the cluster the deployment is checked in. Defaults to 'Seed'.
+	Cluster ClusterType `json:"cluster,omitempty"`
+	// MinReadyReplicas is the minimum number of ready replicas of the deployment. If set, the deployment is considered
+	// healthy as soon as it has at least this number of ready replicas, otherwise it must be fully rolled out.
+	MinReadyReplicas *int32 `json:"minReadyReplicas,omitempty"`
+}
+
+// DaemonSetHealthCheckPolicy declares a health check for a daemon set, which must be fully rolled out.
+type DaemonSetHealthCheckPolicy struct {
+	// Name is the name of the daemon set in the namespace of the extension resource.
+	Name string `json:"name"`
+	// Cluster is the cluster the daemon set is checked in. Defaults to 'Seed'.
+	Cluster ClusterType `json:"cluster,omitempty"`
+}
+
+// ManagedResourceHealthCheckPolicy declares a health check for a managed resource, which must be healthy.
+type ManagedResourceHealthCheckPolicy struct {
+	// Name is the name of the managed resource in the namespace of the extension resource.
+	Name string `json:"name"`
 }
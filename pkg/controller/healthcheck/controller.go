@@ -52,6 +52,8 @@ type AddArgs struct {
 	Type string
 	// SyncPeriod is the duration how often the registered extension is being reconciled
 	SyncPeriod metav1.Duration
+	// Thresholds configures how many consecutive check runs are required before a condition changes its status.
+	Thresholds *healthcheckconfig.HealthCheckThresholds
 	// registeredExtension is the registered extensions that the HealthCheck Controller watches and writes HealthConditions for.
 	// The Gardenlet reads the conditions on the extension Resource.
 	// Through this mechanism, the extension can contribute to the Shoot's HealthStatus.
@@ -64,6 +66,9 @@ type DefaultAddArgs struct {
 	Controller controller.Options
 	// HealthCheckConfig contains additional config for the health check controller
 	HealthCheckConfig healthcheckconfig.HealthCheckConfig
+	// PolicyHealthChecks creates the health checks declared by HealthCheckConfig.Policies, e.g.
+	// general.HealthChecksFromPolicies. It must be set if policies are configured.
+	PolicyHealthChecks PolicyHealthChecksFunc
 }
 
 // RegisteredExtension is a registered extensions that the HealthCheck Controller watches.
@@ -88,7 +93,13 @@ type RegisteredExtension struct {
 // custom predicates allow for fine-grained control which resources to watch
 // healthChecks defines the checks to execute mapped to the healthConditionType its contributing to (e.g checkDeployment in Seed -> ControlPlaneHealthy).
 // register returns a runtime representation of the extension resource to register it with the controller-runtime
+// the health checks declared in opts.HealthCheckConfig.Policies for the given kind are created with opts.PolicyHealthChecks and executed in addition to healthChecks.
 func DefaultRegistration(extensionType string, kind schema.GroupVersionKind, getExtensionObjFunc GetExtensionObjectFunc, mgr manager.Manager, opts DefaultAddArgs, customPredicates []predicate.Predicate, healthChecks []ConditionTypeToHealthCheck) error {
+	healthChecks, err := withPolicyHealthChecks(kind.Kind, opts.HealthCheckConfig.Policies, opts.PolicyHealthChecks, healthChecks)
+	if err != nil {
+		return err
+	}
+
 	predicates := DefaultPredicates()
 	predicates = append(predicates, customPredicates...)
 
@@ -97,13 +108,14 @@ func DefaultRegistration(extensionType string, kind schema.GroupVersionKind, get
 		Predicates:        predicates,
 		Type:              extensionType,
 		SyncPeriod:        opts.HealthCheckConfig.SyncPeriod,
+		Thresholds:        opts.HealthCheckConfig.Thresholds,
 	}
 
 	if err := args.RegisterExtension(getExtensionObjFunc, getHealthCheckTypes(healthChecks), kind); err != nil {
 		return err
 	}
 
-	healthCheckActuator := NewActuator(args.Type, args.GetExtensionGroupVersionKind().Kind, getExtensionObjFunc, healthChecks)
+	healthCheckActuator := NewActuator(args.Type, args.GetExtensionGroupVersionKind().Kind, getExtensionObjFunc, healthChecks, executionOptions(opts.HealthCheckConfig))
 	return Register(mgr, args, healthCheckActuator)
 }
 
@@ -145,7 +157,7 @@ func DefaultPredicates() []predicate.Predicate {
 // Add creates a new Reconciler and adds it to the Manager.
 // and Start it when the Manager is Started.
 func Register(mgr manager.Manager, args AddArgs, actuator HealthCheckActuator) error {
-	args.ControllerOptions.Reconciler = NewReconciler(mgr, actuator, *args.registeredExtension, args.SyncPeriod)
+	args.ControllerOptions.Reconciler = NewReconciler(mgr, actuator, *args.registeredExtension, args.SyncPeriod, args.Thresholds)
 	return add(mgr, args)
 }
 
@@ -168,6 +180,41 @@ func add(mgr manager.Manager, args AddArgs) error {
 	return ctrl.Watch(&source.Kind{Type: args.registeredExtension.getExtensionObjFunc()}, &handler.EnqueueRequestForObject{}, predicates...)
 }
 
+// PolicyHealthChecksFunc creates the health checks declared by the given policies for the extension resource with the
+// given kind.
+type PolicyHealthChecksFunc func(kind string, policies []healthcheckconfig.HealthCheckPolicy) ([]ConditionTypeToHealthCheck, error)
+
+// withPolicyHealthChecks returns the given health checks together with the health checks declared by the given policies
+// for the extension resource with the given kind, which are created with the given function.
+func withPolicyHealthChecks(kind string, policies []healthcheckconfig.HealthCheckPolicy, policyHealthChecks PolicyHealthChecksFunc, healthChecks []ConditionTypeToHealthCheck) ([]ConditionTypeToHealthCheck, error) {
+	if len(policies) == 0 {
+		return healthChecks, nil
+	}
+	if policyHealthChecks == nil {
+		return nil, fmt.Errorf("health check policies are configured but no function to create their health checks is given")
+	}
+
+	policyChecks, err := policyHealthChecks(kind, policies)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ConditionTypeToHealthCheck, 0, len(healthChecks)+len(policyChecks))
+	result = append(result, healthChecks...)
+	return append(result, policyChecks...), nil
+}
+
+func executionOptions(config healthcheckconfig.HealthCheckConfig) ExecutionOptions {
+	opts := ExecutionOptions{}
+	if config.CheckTimeout != nil {
+		opts.CheckTimeout = config.CheckTimeout.Duration
+	}
+	if config.MaxConcurrentChecks != nil {
+		opts.MaxConcurrentChecks = int(*config.MaxConcurrentChecks)
+	}
+	return opts
+}
+
 func getHealthCheckTypes(healthChecks []ConditionTypeToHealthCheck) []string {
 	types := sets.NewString()
 	for _, healthCheck := range healthChecks {
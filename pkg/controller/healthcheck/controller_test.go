@@ -0,0 +1,70 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+
+	healthcheckconfig "github.com/gardener/gardener-extensions/pkg/controller/healthcheck/config"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Controller", func() {
+	Describe("#withPolicyHealthChecks", func() {
+		policies := []healthcheckconfig.HealthCheckPolicy{
+			{Extension: "Worker", ConditionType: "PolicyHealthy"},
+		}
+
+		It("should execute the health checks declared only by policies", func() {
+			block := make(chan struct{})
+			close(block)
+			policyCheck := &fakeHealthCheck{block: block, result: &SingleCheckResult{IsHealthy: false, Detail: "policy check failed"}}
+
+			policyHealthChecks := func(kind string, policies []healthcheckconfig.HealthCheckPolicy) ([]ConditionTypeToHealthCheck, error) {
+				Expect(kind).To(Equal("Worker"))
+				return []ConditionTypeToHealthCheck{{ConditionType: policies[0].ConditionType, HealthCheck: policyCheck}}, nil
+			}
+
+			healthChecks, err := withPolicyHealthChecks("Worker", policies, policyHealthChecks, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(getHealthCheckTypes(healthChecks)).To(ConsistOf("PolicyHealthy"))
+
+			a := NewActuator("test", "Worker", nil, healthChecks, ExecutionOptions{}).(*Actuator)
+			results := a.executeHealthChecks(context.TODO(), types.NamespacedName{Namespace: "shoot--foo--bar", Name: "foo"}, a.healthChecks, func(HealthCheck) {})
+			Expect(*results).To(HaveLen(1))
+			Expect((*results)[0].HealthConditionType).To(Equal("PolicyHealthy"))
+			Expect((*results)[0].IsHealthy).To(BeFalse())
+			Expect((*results)[0].UnsuccessfulChecks).To(Equal(1))
+		})
+
+		It("should fail if the policy health checks can't be created", func() {
+			policyHealthChecks := func(string, []healthcheckconfig.HealthCheckPolicy) ([]ConditionTypeToHealthCheck, error) {
+				return nil, fmt.Errorf("invalid policy")
+			}
+
+			_, err := withPolicyHealthChecks("Worker", policies, policyHealthChecks, nil)
+			Expect(err).To(MatchError("invalid policy"))
+		})
+
+		It("should fail if policies are configured but no function to create their health checks is given", func() {
+			_, err := withPolicyHealthChecks("Worker", policies, nil, nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
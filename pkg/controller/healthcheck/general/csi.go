@@ -0,0 +1,224 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package general
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/healthcheck"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	storagev1beta1 "k8s.io/api/storage/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// DefaultPVCPendingThreshold is the default duration a PVC provisioned by a CSI driver may be pending before it is
+	// considered to be stuck.
+	DefaultPVCPendingThreshold = 5 * time.Minute
+	// DefaultDriverRegistrationGracePeriod is the default duration after a node became ready within which the CSI driver
+	// must be registered on it.
+	DefaultDriverRegistrationGracePeriod = 2 * time.Minute
+
+	// annotationStorageProvisioner is set by the persistent volume controller on PVCs whose volume shall be provisioned
+	// by an external provisioner, e.g. a CSI driver.
+	annotationStorageProvisioner = "volume.beta.kubernetes.io/storage-provisioner"
+)
+
+// CSIHealthChecker contains all the information for the CSI HealthCheck
+type CSIHealthChecker struct {
+	logger                        logr.Logger
+	seedClient                    client.Client
+	shootClient                   client.Client
+	driverName                    string
+	pvcPendingThreshold           time.Duration
+	driverRegistrationGracePeriod time.Duration
+	now                           func() time.Time
+}
+
+// NewShootCSIHealthChecker is a healthCheck function to check the storage managed by the CSI driver with the given name
+// in the Shoot cluster. It checks that
+// - no PersistentVolumeClaim to be provisioned by the driver is pending for longer than the given threshold,
+// - no VolumeAttachment of the driver reports an attach error and
+// - the driver is registered in the CSINode object of every node which is ready for longer than the given grace period.
+// Provider extensions deploying a CSI driver usually register it for the SystemComponentsHealthy condition.
+func NewShootCSIHealthChecker(driverName string, pvcPendingThreshold, driverRegistrationGracePeriod time.Duration) healthcheck.HealthCheck {
+	return &CSIHealthChecker{
+		driverName:                    driverName,
+		pvcPendingThreshold:           pvcPendingThreshold,
+		driverRegistrationGracePeriod: driverRegistrationGracePeriod,
+		now:                           time.Now,
+	}
+}
+
+// InjectSeedClient injects the seed client
+func (healthChecker *CSIHealthChecker) InjectSeedClient(seedClient client.Client) {
+	healthChecker.seedClient = seedClient
+}
+
+// InjectShootClient injects the shoot client
+func (healthChecker *CSIHealthChecker) InjectShootClient(shootClient client.Client) {
+	healthChecker.shootClient = shootClient
+}
+
+// SetLoggerSuffix injects the logger
+func (healthChecker *CSIHealthChecker) SetLoggerSuffix(provider, extension string) {
+	healthChecker.logger = log.Log.WithName(fmt.Sprintf("%s-%s-healthcheck-csi", provider, extension))
+}
+
+// DeepCopy clones the healthCheck struct by making a copy and returning the pointer to that new copy
+func (healthChecker *CSIHealthChecker) DeepCopy() healthcheck.HealthCheck {
+	copy := *healthChecker
+	return &copy
+}
+
+// Check executes the health check
+func (healthChecker *CSIHealthChecker) Check(ctx context.Context, _ types.NamespacedName) (*healthcheck.SingleCheckResult, error) {
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	if err := healthChecker.shootClient.List(ctx, pvcList); err != nil {
+		err := fmt.Errorf("failed to list persistent volume claims in the shoot: %v", err)
+		healthChecker.logger.Error(err, "Health check failed")
+		return nil, err
+	}
+	if isHealthy, reason, err := pvcsAreNotStuckInPending(pvcList.Items, healthChecker.driverName, healthChecker.now(), healthChecker.pvcPendingThreshold); !isHealthy {
+		return healthChecker.unhealthy(reason, err), nil
+	}
+
+	volumeAttachmentList := &storagev1.VolumeAttachmentList{}
+	if err := healthChecker.shootClient.List(ctx, volumeAttachmentList); err != nil {
+		err := fmt.Errorf("failed to list volume attachments in the shoot: %v", err)
+		healthChecker.logger.Error(err, "Health check failed")
+		return nil, err
+	}
+	if isHealthy, reason, err := volumeAttachmentsHaveNoAttachErrors(volumeAttachmentList.Items, healthChecker.driverName); !isHealthy {
+		return healthChecker.unhealthy(reason, err), nil
+	}
+
+	nodeList := &corev1.NodeList{}
+	if err := healthChecker.shootClient.List(ctx, nodeList); err != nil {
+		err := fmt.Errorf("failed to list nodes in the shoot: %v", err)
+		healthChecker.logger.Error(err, "Health check failed")
+		return nil, err
+	}
+	csiNodeList := &storagev1beta1.CSINodeList{}
+	if err := healthChecker.shootClient.List(ctx, csiNodeList); err != nil {
+		err := fmt.Errorf("failed to list CSI nodes in the shoot: %v", err)
+		healthChecker.logger.Error(err, "Health check failed")
+		return nil, err
+	}
+	if isHealthy, reason, err := readyNodesHaveCSIDriver(nodeList.Items, csiNodeList.Items, healthChecker.driverName, healthChecker.now(), healthChecker.driverRegistrationGracePeriod); !isHealthy {
+		return healthChecker.unhealthy(reason, err), nil
+	}
+
+	return &healthcheck.SingleCheckResult{
+		IsHealthy: true,
+	}, nil
+}
+
+func (healthChecker *CSIHealthChecker) unhealthy(reason *string, err error) *healthcheck.SingleCheckResult {
+	healthChecker.logger.Error(err, "Health check failed")
+	return &healthcheck.SingleCheckResult{
+		IsHealthy: false,
+		Detail:    err.Error(),
+		Reason:    *reason,
+	}
+}
+
+func pvcsAreNotStuckInPending(pvcs []corev1.PersistentVolumeClaim, driverName string, now time.Time, threshold time.Duration) (bool, *string, error) {
+	var stuck []string
+	for _, pvc := range pvcs {
+		if pvc.Status.Phase != corev1.ClaimPending || pvc.Annotations[annotationStorageProvisioner] != driverName {
+			continue
+		}
+		if pending := now.Sub(pvc.CreationTimestamp.Time); pending > threshold {
+			stuck = append(stuck, fmt.Sprintf("%s/%s (pending for %s)", pvc.Namespace, pvc.Name, pending.Round(time.Second)))
+		}
+	}
+
+	if len(stuck) > 0 {
+		reason := "PersistentVolumeClaimsPending"
+		sort.Strings(stuck)
+		err := fmt.Errorf("%d persistent volume claim(s) of driver %s pending for longer than %s: %s", len(stuck), driverName, threshold, strings.Join(stuck, ", "))
+		return false, &reason, err
+	}
+	return true, nil, nil
+}
+
+func volumeAttachmentsHaveNoAttachErrors(volumeAttachments []storagev1.VolumeAttachment, driverName string) (bool, *string, error) {
+	var failed []string
+	for _, volumeAttachment := range volumeAttachments {
+		if volumeAttachment.Spec.Attacher != driverName || volumeAttachment.Status.AttachError == nil {
+			continue
+		}
+		failed = append(failed, fmt.Sprintf("%s (node %s: %s)", volumeAttachment.Name, volumeAttachment.Spec.NodeName, volumeAttachment.Status.AttachError.Message))
+	}
+
+	if len(failed) > 0 {
+		reason := "VolumeAttachmentsFailed"
+		sort.Strings(failed)
+		err := fmt.Errorf("%d volume attachment(s) of driver %s failed: %s", len(failed), driverName, strings.Join(failed, ", "))
+		return false, &reason, err
+	}
+	return true, nil, nil
+}
+
+func readyNodesHaveCSIDriver(nodes []corev1.Node, csiNodes []storagev1beta1.CSINode, driverName string, now time.Time, gracePeriod time.Duration) (bool, *string, error) {
+	nodesWithDriver := make(map[string]struct{}, len(csiNodes))
+	for _, csiNode := range csiNodes {
+		for _, driver := range csiNode.Spec.Drivers {
+			if driver.Name == driverName {
+				nodesWithDriver[csiNode.Name] = struct{}{}
+				break
+			}
+		}
+	}
+
+	var missing []string
+	for _, node := range nodes {
+		// the driver is registered shortly after a node became ready, hence nodes which just became ready are skipped
+		if readySince, ok := nodeReadySince(node); !ok || now.Sub(readySince) < gracePeriod {
+			continue
+		}
+		if _, ok := nodesWithDriver[node.Name]; !ok {
+			missing = append(missing, node.Name)
+		}
+	}
+
+	if len(missing) > 0 {
+		reason := "CSIDriverNotRegistered"
+		sort.Strings(missing)
+		err := fmt.Errorf("driver %s is not registered on %d ready node(s): %s", driverName, len(missing), strings.Join(missing, ", "))
+		return false, &reason, err
+	}
+	return true, nil, nil
+}
+
+// nodeReadySince returns the time since which the given node is ready and whether it is ready at all.
+func nodeReadySince(node corev1.Node) (time.Time, bool) {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.LastTransitionTime.Time, condition.Status == corev1.ConditionTrue
+		}
+	}
+	return time.Time{}, false
+}
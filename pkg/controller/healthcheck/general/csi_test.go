@@ -0,0 +1,132 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package general
+
+import (
+	"context"
+	"time"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/healthcheck"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	storagev1beta1 "k8s.io/api/storage/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("CSI", func() {
+	const driverName = "disk.csi.example.com"
+
+	var (
+		now     = time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+		request = types.NamespacedName{Namespace: "shoot--foo--bar", Name: "foo"}
+
+		readyNode = func(name string) *corev1.Node {
+			return &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+					{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+				}},
+			}
+		}
+		csiNode = func(name string, drivers ...string) *storagev1beta1.CSINode {
+			csiNode := &storagev1beta1.CSINode{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			for _, driver := range drivers {
+				csiNode.Spec.Drivers = append(csiNode.Spec.Drivers, storagev1beta1.CSINodeDriver{Name: driver, NodeID: name})
+			}
+			return csiNode
+		}
+		pendingPVC = func(name, provisioner string, age time.Duration) *corev1.PersistentVolumeClaim {
+			return &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:         "default",
+					Name:              name,
+					CreationTimestamp: metav1.NewTime(now.Add(-age)),
+					Annotations:       map[string]string{annotationStorageProvisioner: provisioner},
+				},
+				Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending},
+			}
+		}
+
+		check = func(objects ...runtime.Object) *healthcheck.SingleCheckResult {
+			checker := NewShootCSIHealthChecker(driverName, DefaultPVCPendingThreshold, DefaultDriverRegistrationGracePeriod).(*CSIHealthChecker)
+			checker.now = func() time.Time { return now }
+			checker.InjectShootClient(fake.NewFakeClient(objects...))
+			checker.SetLoggerSuffix("test", "ControlPlane")
+
+			result, err := checker.Check(context.TODO(), request)
+			Expect(err).NotTo(HaveOccurred())
+			return result
+		}
+	)
+
+	It("should succeed if the storage of the driver is healthy", func() {
+		Expect(check(
+			readyNode("node-1"),
+			csiNode("node-1", "other.csi.example.com", driverName),
+			pendingPVC("young", driverName, time.Minute),
+			pendingPVC("other-driver", "other.csi.example.com", time.Hour),
+		)).To(Equal(&healthcheck.SingleCheckResult{IsHealthy: true}))
+	})
+
+	It("should report persistent volume claims stuck in pending", func() {
+		Expect(check(pendingPVC("old", driverName, time.Hour))).To(Equal(&healthcheck.SingleCheckResult{
+			IsHealthy: false,
+			Detail:    "1 persistent volume claim(s) of driver disk.csi.example.com pending for longer than 5m0s: default/old (pending for 1h0m0s)",
+			Reason:    "PersistentVolumeClaimsPending",
+		}))
+	})
+
+	It("should report volume attachments with attach errors", func() {
+		volumeAttachment := &storagev1.VolumeAttachment{
+			ObjectMeta: metav1.ObjectMeta{Name: "csi-123"},
+			Spec:       storagev1.VolumeAttachmentSpec{Attacher: driverName, NodeName: "node-1"},
+			Status:     storagev1.VolumeAttachmentStatus{AttachError: &storagev1.VolumeError{Message: "disk not found"}},
+		}
+
+		Expect(check(volumeAttachment)).To(Equal(&healthcheck.SingleCheckResult{
+			IsHealthy: false,
+			Detail:    "1 volume attachment(s) of driver disk.csi.example.com failed: csi-123 (node node-1: disk not found)",
+			Reason:    "VolumeAttachmentsFailed",
+		}))
+	})
+
+	It("should report ready nodes without the driver", func() {
+		notReadyNode := readyNode("node-3")
+		notReadyNode.Status.Conditions[0].Status = corev1.ConditionFalse
+
+		Expect(check(
+			readyNode("node-1"), csiNode("node-1", "other.csi.example.com"),
+			readyNode("node-2"),
+			notReadyNode,
+		)).To(Equal(&healthcheck.SingleCheckResult{
+			IsHealthy: false,
+			Detail:    "driver disk.csi.example.com is not registered on 2 ready node(s): node-1, node-2",
+			Reason:    "CSIDriverNotRegistered",
+		}))
+	})
+
+	It("should not report nodes without the driver which just became ready", func() {
+		newNode := readyNode("node-1")
+		newNode.Status.Conditions[0].LastTransitionTime = metav1.NewTime(now.Add(-time.Minute))
+
+		Expect(check(newNode)).To(Equal(&healthcheck.SingleCheckResult{IsHealthy: true}))
+	})
+})
@@ -35,6 +35,8 @@ type DeploymentHealthChecker struct {
 	shootClient client.Client
 	name        string
 	checkType   DeploymentCheckType
+	// minReadyReplicas is the minimum number of ready replicas. If nil, the deployment must be fully rolled out.
+	minReadyReplicas *int32
 }
 
 // DeploymentCheckType in which cluster the check will be executed
@@ -61,6 +63,16 @@ func NewShootDeploymentHealthChecker(deploymentName string) healthcheck.HealthCh
 	}
 }
 
+// NewDeploymentMinReadyReplicasHealthChecker is a healthCheck function to check that Deployments in the Seed or Shoot
+// cluster have at least the given number of ready replicas
+func NewDeploymentMinReadyReplicasHealthChecker(deploymentName string, checkType DeploymentCheckType, minReadyReplicas int32) healthcheck.HealthCheck {
+	return &DeploymentHealthChecker{
+		name:             deploymentName,
+		checkType:        checkType,
+		minReadyReplicas: &minReadyReplicas,
+	}
+}
+
 // InjectSeedClient injects the seed client
 func (healthChecker *DeploymentHealthChecker) InjectSeedClient(seedClient client.Client) {
 	healthChecker.seedClient = seedClient
@@ -98,7 +110,11 @@ func (healthChecker *DeploymentHealthChecker) Check(ctx context.Context, request
 		return nil, err
 	}
 
-	if isHealthy, reason, err := deploymentIsHealthy(deployment); !isHealthy {
+	isHealthy, reason, err := deploymentIsHealthy(deployment)
+	if healthChecker.minReadyReplicas != nil {
+		isHealthy, reason, err = deploymentHasMinReadyReplicas(deployment, *healthChecker.minReadyReplicas)
+	}
+	if !isHealthy {
 		healthChecker.logger.Error(err, "Health check failed")
 		return &healthcheck.SingleCheckResult{
 			IsHealthy: false,
@@ -120,3 +136,12 @@ func deploymentIsHealthy(deployment *appsv1.Deployment) (bool, *string, error) {
 	}
 	return true, nil, nil
 }
+
+func deploymentHasMinReadyReplicas(deployment *appsv1.Deployment, minReadyReplicas int32) (bool, *string, error) {
+	if deployment.Status.ReadyReplicas < minReadyReplicas {
+		reason := "DeploymentUnhealthy"
+		err := fmt.Errorf("deployment %s in namespace %s has only %d of at least %d ready replicas", deployment.Name, deployment.Namespace, deployment.Status.ReadyReplicas, minReadyReplicas)
+		return false, &reason, err
+	}
+	return true, nil, nil
+}
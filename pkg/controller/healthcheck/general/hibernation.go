@@ -0,0 +1,119 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package general
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/healthcheck"
+
+	"github.com/gardener/gardener/pkg/utils/kubernetes/health"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DeploymentHibernationHealthChecker contains all the information for the Deployment hibernation HealthCheck
+type DeploymentHibernationHealthChecker struct {
+	logger      logr.Logger
+	seedClient  client.Client
+	shootClient client.Client
+	name        string
+	transition  healthcheck.HibernationTransition
+	timeout     time.Duration
+	now         func() time.Time
+}
+
+// NewSeedDeploymentHibernationHealthChecker is a hibernation aware healthCheck function to check that a Deployment in
+// the Seed cluster is scaled down while the cluster is hibernating or hibernated, and is scaled up and healthy again
+// while the cluster is waking up. Transitions which do not complete within the given timeout are reported as unhealthy.
+func NewSeedDeploymentHibernationHealthChecker(deploymentName string, timeout time.Duration) healthcheck.HealthCheck {
+	return &DeploymentHibernationHealthChecker{
+		name:    deploymentName,
+		timeout: timeout,
+		now:     time.Now,
+	}
+}
+
+// InjectSeedClient injects the seed client
+func (healthChecker *DeploymentHibernationHealthChecker) InjectSeedClient(seedClient client.Client) {
+	healthChecker.seedClient = seedClient
+}
+
+// InjectShootClient injects the shoot client
+func (healthChecker *DeploymentHibernationHealthChecker) InjectShootClient(shootClient client.Client) {
+	healthChecker.shootClient = shootClient
+}
+
+// InjectHibernationTransition injects the current hibernation state of the cluster
+func (healthChecker *DeploymentHibernationHealthChecker) InjectHibernationTransition(transition healthcheck.HibernationTransition) {
+	healthChecker.transition = transition
+}
+
+// SetLoggerSuffix injects the logger
+func (healthChecker *DeploymentHibernationHealthChecker) SetLoggerSuffix(provider, extension string) {
+	healthChecker.logger = log.Log.WithName(fmt.Sprintf("%s-%s-healthcheck-deployment-hibernation", provider, extension))
+}
+
+// DeepCopy clones the healthCheck struct by making a copy and returning the pointer to that new copy
+func (healthChecker *DeploymentHibernationHealthChecker) DeepCopy() healthcheck.HealthCheck {
+	copy := *healthChecker
+	return &copy
+}
+
+// Check executes the health check
+func (healthChecker *DeploymentHibernationHealthChecker) Check(ctx context.Context, request types.NamespacedName) (*healthcheck.SingleCheckResult, error) {
+	deployment := &appsv1.Deployment{}
+	if err := healthChecker.seedClient.Get(ctx, client.ObjectKey{Namespace: request.Namespace, Name: healthChecker.name}, deployment); err != nil {
+		err := fmt.Errorf("failed to retrieve deployment '%s' in namespace '%s': %v", healthChecker.name, request.Namespace, err)
+		healthChecker.logger.Error(err, "Health check failed")
+		return nil, err
+	}
+
+	var err error
+	if healthChecker.transition.State == healthcheck.HibernationStateWakingUp {
+		err = deploymentIsWokenUp(deployment)
+	} else {
+		err = deploymentIsScaledDown(deployment)
+	}
+
+	result := healthcheck.HibernationTransitionResult(healthChecker.transition, healthChecker.timeout, healthChecker.now(), err)
+	if !result.IsHealthy {
+		healthChecker.logger.Error(errors.New(result.Detail), "Health check failed")
+	}
+	return result, nil
+}
+
+func deploymentIsScaledDown(deployment *appsv1.Deployment) error {
+	if (deployment.Spec.Replicas != nil && *deployment.Spec.Replicas != 0) || deployment.Status.Replicas != 0 {
+		return fmt.Errorf("deployment %s in namespace %s is not scaled down (%d replicas)", deployment.Name, deployment.Namespace, deployment.Status.Replicas)
+	}
+	return nil
+}
+
+func deploymentIsWokenUp(deployment *appsv1.Deployment) error {
+	if deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == 0 {
+		return fmt.Errorf("deployment %s in namespace %s is not scaled up", deployment.Name, deployment.Namespace)
+	}
+	if err := health.CheckDeployment(deployment); err != nil {
+		return fmt.Errorf("deployment %s in namespace %s is unhealthy: %v", deployment.Name, deployment.Namespace, err)
+	}
+	return nil
+}
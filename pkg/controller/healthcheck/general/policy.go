@@ -0,0 +1,119 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package general
+
+import (
+	"fmt"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/healthcheck"
+	healthcheckconfig "github.com/gardener/gardener-extensions/pkg/controller/healthcheck/config"
+)
+
+// HealthChecksFromPolicies creates the health checks declared by the given policies for the extension resource with the
+// given kind (e.g. 'ControlPlane' or 'Worker'). Policies for other kinds are ignored. It can be passed as
+// healthcheck.DefaultAddArgs.PolicyHealthChecks, so that healthcheck.DefaultRegistration executes the checks.
+func HealthChecksFromPolicies(kind string, policies []healthcheckconfig.HealthCheckPolicy) ([]healthcheck.ConditionTypeToHealthCheck, error) {
+	var healthChecks []healthcheck.ConditionTypeToHealthCheck
+
+	for i, policy := range policies {
+		if policy.Extension != kind {
+			continue
+		}
+
+		check, err := healthCheckFromPolicy(policy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid health check policy %d for %s: %v", i, kind, err)
+		}
+
+		healthChecks = append(healthChecks, healthcheck.ConditionTypeToHealthCheck{
+			ConditionType: policy.ConditionType,
+			HealthCheck:   check,
+		})
+	}
+
+	return healthChecks, nil
+}
+
+func healthCheckFromPolicy(policy healthcheckconfig.HealthCheckPolicy) (healthcheck.HealthCheck, error) {
+	if len(policy.ConditionType) == 0 {
+		return nil, fmt.Errorf("condition type must be set")
+	}
+
+	var (
+		check healthcheck.HealthCheck
+		count int
+	)
+
+	if p := policy.Deployment; p != nil {
+		count++
+		if len(p.Name) == 0 {
+			return nil, fmt.Errorf("deployment name must be set")
+		}
+		checkType, err := deploymentCheckType(p.Cluster)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case p.MinReadyReplicas != nil:
+			check = NewDeploymentMinReadyReplicasHealthChecker(p.Name, checkType, *p.MinReadyReplicas)
+		case checkType == DeploymentCheckTypeShoot:
+			check = NewShootDeploymentHealthChecker(p.Name)
+		default:
+			check = NewSeedDeploymentHealthChecker(p.Name)
+		}
+	}
+
+	if p := policy.DaemonSet; p != nil {
+		count++
+		if len(p.Name) == 0 {
+			return nil, fmt.Errorf("daemon set name must be set")
+		}
+		checkType, err := deploymentCheckType(p.Cluster)
+		if err != nil {
+			return nil, err
+		}
+
+		if checkType == DeploymentCheckTypeShoot {
+			check = NewShootDaemonSetHealthChecker(p.Name)
+		} else {
+			check = NewSeedDaemonSetHealthChecker(p.Name)
+		}
+	}
+
+	if p := policy.ManagedResource; p != nil {
+		count++
+		if len(p.Name) == 0 {
+			return nil, fmt.Errorf("managed resource name must be set")
+		}
+		check = CheckManagedResource(p.Name)
+	}
+
+	if count != 1 {
+		return nil, fmt.Errorf("exactly one check must be set, but found %d", count)
+	}
+	return check, nil
+}
+
+func deploymentCheckType(cluster healthcheckconfig.ClusterType) (DeploymentCheckType, error) {
+	switch cluster {
+	case "", healthcheckconfig.ClusterTypeSeed:
+		return DeploymentCheckTypeSeed, nil
+	case healthcheckconfig.ClusterTypeShoot:
+		return DeploymentCheckTypeShoot, nil
+	default:
+		return "", fmt.Errorf("unsupported cluster %q", cluster)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package general
+
+import (
+	"context"
+
+	healthcheckconfig "github.com/gardener/gardener-extensions/pkg/controller/healthcheck/config"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Policies", func() {
+	var minReadyReplicas int32 = 2
+
+	Describe("#HealthChecksFromPolicies", func() {
+		It("should create the health checks for the given kind", func() {
+			policies := []healthcheckconfig.HealthCheckPolicy{
+				{
+					Extension:     "ControlPlane",
+					ConditionType: "ControlPlaneHealthy",
+					Deployment:    &healthcheckconfig.DeploymentHealthCheckPolicy{Name: "foo", MinReadyReplicas: &minReadyReplicas},
+				},
+				{
+					Extension:     "Worker",
+					ConditionType: "SystemComponentsHealthy",
+					DaemonSet:     &healthcheckconfig.DaemonSetHealthCheckPolicy{Name: "bar", Cluster: healthcheckconfig.ClusterTypeShoot},
+				},
+				{
+					Extension:       "ControlPlane",
+					ConditionType:   "SystemComponentsHealthy",
+					ManagedResource: &healthcheckconfig.ManagedResourceHealthCheckPolicy{Name: "baz"},
+				},
+			}
+
+			healthChecks, err := HealthChecksFromPolicies("ControlPlane", policies)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(healthChecks).To(HaveLen(2))
+			Expect(healthChecks[0].ConditionType).To(Equal("ControlPlaneHealthy"))
+			Expect(healthChecks[0].HealthCheck).To(Equal(NewDeploymentMinReadyReplicasHealthChecker("foo", DeploymentCheckTypeSeed, 2)))
+			Expect(healthChecks[1].ConditionType).To(Equal("SystemComponentsHealthy"))
+			Expect(healthChecks[1].HealthCheck).To(Equal(CheckManagedResource("baz")))
+		})
+
+		It("should fail if a policy declares no or multiple checks", func() {
+			_, err := HealthChecksFromPolicies("Worker", []healthcheckconfig.HealthCheckPolicy{
+				{Extension: "Worker", ConditionType: "SystemComponentsHealthy"},
+			})
+			Expect(err).To(HaveOccurred())
+
+			_, err = HealthChecksFromPolicies("Worker", []healthcheckconfig.HealthCheckPolicy{
+				{
+					Extension:       "Worker",
+					ConditionType:   "SystemComponentsHealthy",
+					DaemonSet:       &healthcheckconfig.DaemonSetHealthCheckPolicy{Name: "bar"},
+					ManagedResource: &healthcheckconfig.ManagedResourceHealthCheckPolicy{Name: "baz"},
+				},
+			})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail if a policy declares an unsupported cluster", func() {
+			_, err := HealthChecksFromPolicies("Worker", []healthcheckconfig.HealthCheckPolicy{
+				{
+					Extension:     "Worker",
+					ConditionType: "SystemComponentsHealthy",
+					DaemonSet:     &healthcheckconfig.DaemonSetHealthCheckPolicy{Name: "bar", Cluster: "Garden"},
+				},
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#NewDeploymentMinReadyReplicasHealthChecker", func() {
+		check := func(readyReplicas int32) bool {
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "shoot--foo--bar", Name: "foo"},
+				Status:     appsv1.DeploymentStatus{ReadyReplicas: readyReplicas},
+			}
+
+			healthCheck := NewDeploymentMinReadyReplicasHealthChecker("foo", DeploymentCheckTypeSeed, minReadyReplicas)
+			healthCheck.SetLoggerSuffix("test", "test")
+			healthCheck.InjectSeedClient(fake.NewFakeClient(deployment))
+
+			result, err := healthCheck.Check(context.TODO(), types.NamespacedName{Namespace: "shoot--foo--bar", Name: "foo"})
+			Expect(err).NotTo(HaveOccurred())
+			return result.IsHealthy
+		}
+
+		It("should be healthy if the deployment has enough ready replicas", func() {
+			Expect(check(2)).To(BeTrue())
+		})
+
+		It("should be unhealthy if the deployment has too few ready replicas", func() {
+			Expect(check(1)).To(BeFalse())
+		})
+	})
+})
@@ -19,10 +19,12 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
 	"github.com/gardener/gardener-extensions/pkg/util"
 
+	"github.com/gardener/gardener/pkg/utils/secrets"
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
@@ -45,16 +47,44 @@ type Actuator struct {
 	extensionKind       string
 	getExtensionObjFunc GetExtensionObjectFunc
 	healthChecks        []ConditionTypeToHealthCheck
+
+	checkTimeout time.Duration
+	// slots bounds the number of health checks that are executed concurrently
+	slots chan struct{}
+}
+
+const (
+	// DefaultCheckTimeout is the default maximum duration of a single health check.
+	DefaultCheckTimeout = 30 * time.Second
+	// DefaultMaxConcurrentChecks is the default maximum number of health checks executed concurrently by an actuator.
+	DefaultMaxConcurrentChecks = 20
+)
+
+// ExecutionOptions configure how the health checks are executed.
+type ExecutionOptions struct {
+	// CheckTimeout is the maximum duration of a single health check. Defaults to DefaultCheckTimeout.
+	CheckTimeout time.Duration
+	// MaxConcurrentChecks is the maximum number of health checks executed concurrently. Defaults to DefaultMaxConcurrentChecks.
+	MaxConcurrentChecks int
 }
 
 // NewActuator creates a new Actuator.
-func NewActuator(provider, extensionKind string, getExtensionObjFunc GetExtensionObjectFunc, healthChecks []ConditionTypeToHealthCheck) HealthCheckActuator {
+func NewActuator(provider, extensionKind string, getExtensionObjFunc GetExtensionObjectFunc, healthChecks []ConditionTypeToHealthCheck, opts ExecutionOptions) HealthCheckActuator {
+	if opts.CheckTimeout <= 0 {
+		opts.CheckTimeout = DefaultCheckTimeout
+	}
+	if opts.MaxConcurrentChecks <= 0 {
+		opts.MaxConcurrentChecks = DefaultMaxConcurrentChecks
+	}
+
 	return &Actuator{
 		healthChecks:        healthChecks,
 		getExtensionObjFunc: getExtensionObjFunc,
 		provider:            provider,
 		extensionKind:       extensionKind,
 		logger:              log.Log.WithName(fmt.Sprintf("%s-%s-healthcheck-actuator", provider, extensionKind)),
+		checkTimeout:        opts.CheckTimeout,
+		slots:               make(chan struct{}, opts.MaxConcurrentChecks),
 	}
 }
 
@@ -92,31 +122,92 @@ type checkResultForConditionType struct {
 }
 
 // ExecuteHealthCheckFunctions executes all the health check functions, injects clients and logger & aggregates the results.
+// The health checks are executed concurrently, each with its own deadline. Health checks exceeding their deadline are
+// reported as failed checks.
+// Hibernation aware health checks are not executed.
 // returns an Result for each HealthConditionTyp (e.g  ControlPlaneHealthy)
 func (a *Actuator) ExecuteHealthCheckFunctions(ctx context.Context, request types.NamespacedName) (*[]Result, error) {
-	_, shootClient, err := util.NewClientForShoot(ctx, a.seedClient, request.Namespace, client.Options{})
+	shootClient, err := a.newShootClient(ctx, request.Namespace)
 	if err != nil {
 		msg := fmt.Errorf("failed to create shoot client in namespace '%s'", request.Namespace)
 		a.logger.Error(err, msg.Error())
 		return nil, msg
 	}
 
+	var healthChecks []ConditionTypeToHealthCheck
+	for _, hc := range a.healthChecks {
+		if _, ok := hc.HealthCheck.(HibernationAwareHealthCheck); !ok {
+			healthChecks = append(healthChecks, hc)
+		}
+	}
+
+	return a.executeHealthChecks(ctx, request, healthChecks, func(check HealthCheck) {
+		check.InjectShootClient(shootClient)
+	}), nil
+}
+
+// ExecuteHibernationHealthCheckFunctions executes the hibernation aware health check functions, injects the seed client,
+// the logger and the given hibernation transition & aggregates the results.
+// returns an Result for each HealthConditionTyp with at least one hibernation aware health check
+func (a *Actuator) ExecuteHibernationHealthCheckFunctions(ctx context.Context, request types.NamespacedName, transition HibernationTransition) (*[]Result, error) {
+	var healthChecks []ConditionTypeToHealthCheck
+	for _, hc := range a.healthChecks {
+		if _, ok := hc.HealthCheck.(HibernationAwareHealthCheck); ok {
+			healthChecks = append(healthChecks, hc)
+		}
+	}
+
+	return a.executeHealthChecks(ctx, request, healthChecks, func(check HealthCheck) {
+		check.InjectShootClient(nil)
+		if hibernationAwareCheck, ok := check.(HibernationAwareHealthCheck); ok {
+			hibernationAwareCheck.InjectHibernationTransition(transition)
+		}
+	}), nil
+}
+
+// executeHealthChecks executes the given health checks concurrently and aggregates their results per condition type.
+// The given inject function is called for every cloned health check before it is executed.
+func (a *Actuator) executeHealthChecks(ctx context.Context, request types.NamespacedName, healthChecks []ConditionTypeToHealthCheck, inject func(HealthCheck)) *[]Result {
 	var (
 		channel = make(chan channelResult)
 		wg      sync.WaitGroup
 	)
 
-	wg.Add(len(a.healthChecks))
-	for _, hc := range a.healthChecks {
+	wg.Add(len(healthChecks))
+	for _, hc := range healthChecks {
 		// clone to avoid problems during parallel execution
 		check := hc.HealthCheck.DeepCopy()
 		check.InjectSeedClient(a.seedClient)
-		check.InjectShootClient(shootClient)
+		inject(check)
 		check.SetLoggerSuffix(a.provider, a.extensionKind)
 
 		go func(ctx context.Context, request types.NamespacedName, check HealthCheck, preCheckFunc PreCheckFunc, healthConditionType string) {
 			defer wg.Done()
 
+			// wait for a free slot to bound the number of concurrently executed checks
+			var (
+				releaseSlot  = func() { <-a.slots }
+				checkStarted bool
+			)
+			select {
+			case a.slots <- struct{}{}:
+				// once the check is started, the slot is released when the check returns, see checkWithTimeout
+				defer func() {
+					if !checkStarted {
+						releaseSlot()
+					}
+				}()
+			case <-ctx.Done():
+				channel <- channelResult{
+					error:               fmt.Errorf("health check could not be started: %v", ctx.Err()),
+					healthConditionType: healthConditionType,
+				}
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(ctx, a.checkTimeout)
+			defer cancel()
+
 			if preCheckFunc != nil {
 				obj := a.getExtensionObjFunc()
 				if err := a.seedClient.Get(ctx, client.ObjectKey{Namespace: request.Namespace, Name: request.Name}, obj); err != nil {
@@ -159,7 +250,10 @@ func (a *Actuator) ExecuteHealthCheckFunctions(ctx context.Context, request type
 				}
 			}
 
-			healthCheckResult, err := check.Check(ctx, request)
+			start := time.Now()
+			checkStarted = true
+			healthCheckResult, err := a.checkWithTimeout(ctx, check, request, releaseSlot)
+			a.recordMetrics(check, healthConditionType, time.Since(start), healthCheckResult, err)
 			channel <- channelResult{
 				healthCheckResult:   healthCheckResult,
 				error:               err,
@@ -224,5 +318,68 @@ func (a *Actuator) ExecuteHealthCheckFunctions(ctx context.Context, request type
 			SuccessfulChecks:    result.successfulChecks,
 		})
 	}
-	return &checkResults, nil
+	return &checkResults
+}
+
+func (a *Actuator) recordMetrics(check HealthCheck, healthConditionType string, duration time.Duration, result *SingleCheckResult, err error) {
+	name := checkName(check)
+	checkDuration.WithLabelValues(a.extensionKind, a.provider, healthConditionType, name).Observe(duration.Seconds())
+
+	outcome := checkResultSuccess
+	switch {
+	case err != nil:
+		outcome = checkResultError
+	case result == nil || !result.IsHealthy:
+		outcome = checkResultFailure
+	}
+	checkResults.WithLabelValues(a.extensionKind, a.provider, healthConditionType, name, outcome).Inc()
+}
+
+type checkResult struct {
+	result *SingleCheckResult
+	err    error
+}
+
+// checkWithTimeout executes the given health check and returns an error if it does not finish before the deadline of
+// the given context, even if the check itself does not respect the context. The given release function is called when
+// the check returns, which may be after checkWithTimeout returned. This way, a check that does not respect the context
+// keeps occupying its slot and hung checks can't exceed the maximum number of concurrently executed checks.
+func (a *Actuator) checkWithTimeout(ctx context.Context, check HealthCheck, request types.NamespacedName, release func()) (*SingleCheckResult, error) {
+	resultCh := make(chan checkResult, 1)
+	go func() {
+		defer release()
+		result, err := check.Check(ctx, request)
+		resultCh <- checkResult{result: result, err: err}
+	}()
+
+	select {
+	case r := <-resultCh:
+		if r.err != nil && ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("health check timed out after %s: %v", a.checkTimeout, r.err)
+		}
+		return r.result, r.err
+	case <-ctx.Done():
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("health check timed out after %s", a.checkTimeout)
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// newShootClient creates a client for the shoot cluster in the given namespace. The requests of the client, including
+// the discovery performed when creating it, are bounded by the check timeout, so that an unreachable shoot API server
+// does not stall the health checks.
+func (a *Actuator) newShootClient(ctx context.Context, namespace string) (client.Client, error) {
+	secret, err := util.GetKubeconfigSecretForShoot(ctx, a.seedClient, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	restConfig, err := util.NewRESTConfigFromKubeconfig(secret.Data[secrets.DataKeyKubeconfig])
+	if err != nil {
+		return nil, err
+	}
+	restConfig.Timeout = a.checkTimeout
+
+	return client.New(restConfig, client.Options{})
 }
@@ -0,0 +1,101 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheck
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type fakeHealthCheck struct {
+	block  chan struct{}
+	result *SingleCheckResult
+}
+
+func (f *fakeHealthCheck) Check(ctx context.Context, request types.NamespacedName) (*SingleCheckResult, error) {
+	<-f.block
+	return f.result, nil
+}
+
+func (f *fakeHealthCheck) InjectSeedClient(client.Client)  {}
+func (f *fakeHealthCheck) InjectShootClient(client.Client) {}
+func (f *fakeHealthCheck) SetLoggerSuffix(string, string)  {}
+func (f *fakeHealthCheck) DeepCopy() HealthCheck           { return f }
+
+var _ = Describe("Actuator", func() {
+	var request = types.NamespacedName{Namespace: "shoot--foo--bar", Name: "foo"}
+
+	Describe("#NewActuator", func() {
+		It("should default the execution options", func() {
+			a := NewActuator("test", "Worker", nil, nil, ExecutionOptions{}).(*Actuator)
+			Expect(a.checkTimeout).To(Equal(DefaultCheckTimeout))
+			Expect(cap(a.slots)).To(Equal(DefaultMaxConcurrentChecks))
+		})
+	})
+
+	Describe("#checkWithTimeout", func() {
+		var (
+			a     *Actuator
+			check *fakeHealthCheck
+		)
+
+		BeforeEach(func() {
+			a = NewActuator("test", "Worker", nil, nil, ExecutionOptions{CheckTimeout: 50 * time.Millisecond}).(*Actuator)
+			check = &fakeHealthCheck{block: make(chan struct{}), result: &SingleCheckResult{IsHealthy: true}}
+		})
+
+		AfterEach(func() {
+			close(check.block)
+		})
+
+		It("should return the result of a check finishing in time", func() {
+			check.block = make(chan struct{}, 1)
+			check.block <- struct{}{}
+
+			ctx, cancel := context.WithTimeout(context.TODO(), a.checkTimeout)
+			defer cancel()
+
+			result, err := a.checkWithTimeout(ctx, check, request, func() {})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.IsHealthy).To(BeTrue())
+		})
+
+		It("should fail for a check exceeding the timeout", func() {
+			ctx, cancel := context.WithTimeout(context.TODO(), a.checkTimeout)
+			defer cancel()
+
+			_, err := a.checkWithTimeout(ctx, check, request, func() {})
+			Expect(err).To(MatchError(ContainSubstring("health check timed out after 50ms")))
+		})
+
+		It("should release the slot of a check exceeding the timeout only when the check returns", func() {
+			ctx, cancel := context.WithTimeout(context.TODO(), a.checkTimeout)
+			defer cancel()
+
+			a.slots <- struct{}{}
+			_, err := a.checkWithTimeout(ctx, check, request, func() { <-a.slots })
+			Expect(err).To(HaveOccurred())
+			Expect(a.slots).To(HaveLen(1))
+
+			check.block <- struct{}{}
+			Eventually(a.slots).Should(BeEmpty())
+		})
+	})
+})
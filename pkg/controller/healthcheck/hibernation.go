@@ -0,0 +1,161 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// HibernationState describes whether a cluster is awake, hibernated or in transition between both.
+type HibernationState string
+
+const (
+	// HibernationStateAwake is the state of a cluster which is neither hibernated nor shall be hibernated.
+	HibernationStateAwake HibernationState = "Awake"
+	// HibernationStateHibernating is the state of a cluster which shall be hibernated but is not yet hibernated.
+	HibernationStateHibernating HibernationState = "Hibernating"
+	// HibernationStateHibernated is the state of a cluster which is hibernated.
+	HibernationStateHibernated HibernationState = "Hibernated"
+	// HibernationStateWakingUp is the state of a cluster which is hibernated but shall be woken up.
+	HibernationStateWakingUp HibernationState = "WakingUp"
+)
+
+// GetHibernationState returns the hibernation state of the given cluster based on the desired hibernation in the
+// shoot spec and the observed hibernation in the shoot status.
+func GetHibernationState(cluster *extensionscontroller.Cluster) HibernationState {
+	var (
+		shouldBeHibernated = extensionscontroller.IsHibernated(cluster)
+		isHibernated       = cluster.Shoot.Status.IsHibernated
+	)
+
+	switch {
+	case shouldBeHibernated && isHibernated:
+		return HibernationStateHibernated
+	case shouldBeHibernated:
+		return HibernationStateHibernating
+	case isHibernated:
+		return HibernationStateWakingUp
+	default:
+		return HibernationStateAwake
+	}
+}
+
+// DefaultHibernationTransitionTimeout is the default duration after which a hibernation transition which did not reach
+// its target state is considered to be stuck.
+const DefaultHibernationTransitionTimeout = 15 * time.Minute
+
+// HibernationTransition is the hibernation state of a cluster together with the time the state was first observed.
+type HibernationTransition struct {
+	State HibernationState
+	Since time.Time
+}
+
+// HibernationAwareHealthCheck is a HealthCheck which is only executed while the cluster is hibernating, hibernated or
+// waking up. All other health checks are skipped in these phases, and hibernation aware health checks are skipped while
+// the cluster is awake. Conditions with only hibernation aware health checks are reported as successful while the
+// cluster is awake.
+// As the shoot API server might not be available in these phases, hibernation aware health checks must only use the
+// seed client, the injected shoot client is nil.
+type HibernationAwareHealthCheck interface {
+	HealthCheck
+	// InjectHibernationTransition injects the current hibernation state of the cluster
+	InjectHibernationTransition(HibernationTransition)
+}
+
+// HibernationHealthCheckActuator is a HealthCheckActuator which can execute the hibernation aware health checks.
+type HibernationHealthCheckActuator interface {
+	HealthCheckActuator
+	// ExecuteHibernationHealthCheckFunctions executes the registered hibernation aware health checks and aggregates the
+	// results. Only condition types with at least one hibernation aware health check are contained in the result.
+	ExecuteHibernationHealthCheckFunctions(context.Context, types.NamespacedName, HibernationTransition) (*[]Result, error)
+}
+
+// HibernationTransitionResult returns the result of a hibernation aware health check. The given error describes why the
+// target state of the current hibernation transition (scaled down while hibernating or hibernated, scaled up while
+// waking up) is not yet reached; nil means that it is reached.
+// A transition which did not reach its target state is considered healthy as long as it is not running for longer than
+// the given timeout. Not having reached the target state while hibernated is always unhealthy.
+func HibernationTransitionResult(transition HibernationTransition, timeout time.Duration, now time.Time, err error) *SingleCheckResult {
+	if err == nil {
+		return &SingleCheckResult{IsHealthy: true}
+	}
+
+	var (
+		duration = now.Sub(transition.Since).Round(time.Second)
+		reason   = "HibernationStuck"
+	)
+	if transition.State == HibernationStateWakingUp {
+		reason = "WakeUpStuck"
+	}
+
+	switch {
+	case transition.State == HibernationStateHibernated:
+		return &SingleCheckResult{
+			IsHealthy: false,
+			Detail:    fmt.Sprintf("cluster is hibernated, but %v", err),
+			Reason:    reason,
+		}
+	case duration <= timeout:
+		return &SingleCheckResult{
+			IsHealthy: true,
+			Detail:    fmt.Sprintf("%s since %s: %v", transition.State, duration, err),
+		}
+	default:
+		return &SingleCheckResult{
+			IsHealthy: false,
+			Detail:    fmt.Sprintf("%s for %s (timeout %s): %v", transition.State, duration, timeout, err),
+			Reason:    reason,
+		}
+	}
+}
+
+// hibernationTransitions remembers when the current hibernation state of the extension resources was first observed.
+type hibernationTransitions struct {
+	lock        sync.Mutex
+	transitions map[types.NamespacedName]HibernationTransition
+}
+
+func newHibernationTransitions() *hibernationTransitions {
+	return &hibernationTransitions{transitions: make(map[types.NamespacedName]HibernationTransition)}
+}
+
+// observe records the given hibernation state of the given resource and returns the transition, i.e. the state and the
+// time it was first observed.
+func (h *hibernationTransitions) observe(resource types.NamespacedName, state HibernationState, now time.Time) HibernationTransition {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if transition, ok := h.transitions[resource]; ok && transition.State == state {
+		return transition
+	}
+	transition := HibernationTransition{State: state, Since: now}
+	h.transitions[resource] = transition
+	return transition
+}
+
+// forget removes the recorded hibernation state of the given resource.
+func (h *hibernationTransitions) forget(resource types.NamespacedName) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	delete(h.transitions, resource)
+}
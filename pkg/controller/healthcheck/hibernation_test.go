@@ -0,0 +1,132 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type fakeHibernationHealthCheck struct {
+	fakeHealthCheck
+	transition HibernationTransition
+}
+
+func (f *fakeHibernationHealthCheck) Check(context.Context, types.NamespacedName) (*SingleCheckResult, error) {
+	return &SingleCheckResult{IsHealthy: f.transition.State == HibernationStateHibernated}, nil
+}
+
+func (f *fakeHibernationHealthCheck) InjectHibernationTransition(transition HibernationTransition) {
+	f.transition = transition
+}
+
+func (f *fakeHibernationHealthCheck) DeepCopy() HealthCheck {
+	copy := *f
+	return &copy
+}
+
+var _ = Describe("Hibernation", func() {
+	var (
+		now      = time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+		resource = types.NamespacedName{Namespace: "shoot--foo--bar", Name: "foo"}
+	)
+
+	DescribeTable("#GetHibernationState",
+		func(enabled *bool, isHibernated bool, expected HibernationState) {
+			cluster := &extensionscontroller.Cluster{Shoot: &gardencorev1beta1.Shoot{
+				Spec:   gardencorev1beta1.ShootSpec{Hibernation: &gardencorev1beta1.Hibernation{Enabled: enabled}},
+				Status: gardencorev1beta1.ShootStatus{IsHibernated: isHibernated},
+			}}
+			Expect(GetHibernationState(cluster)).To(Equal(expected))
+		},
+		Entry("awake", nil, false, HibernationStateAwake),
+		Entry("hibernating", boolPtr(true), false, HibernationStateHibernating),
+		Entry("hibernated", boolPtr(true), true, HibernationStateHibernated),
+		Entry("waking up", boolPtr(false), true, HibernationStateWakingUp),
+	)
+
+	Describe("#HibernationTransitionResult", func() {
+		var errNotReached = errors.New("2 machines left")
+
+		It("should be healthy if the target state is reached", func() {
+			transition := HibernationTransition{State: HibernationStateHibernating, Since: now.Add(-time.Hour)}
+			Expect(HibernationTransitionResult(transition, time.Minute, now, nil)).To(Equal(&SingleCheckResult{IsHealthy: true}))
+		})
+
+		It("should be healthy if the transition is in progress", func() {
+			transition := HibernationTransition{State: HibernationStateHibernating, Since: now.Add(-time.Minute)}
+			result := HibernationTransitionResult(transition, 5*time.Minute, now, errNotReached)
+			Expect(result.IsHealthy).To(BeTrue())
+			Expect(result.Detail).To(Equal("Hibernating since 1m0s: 2 machines left"))
+		})
+
+		It("should be unhealthy if the transition exceeds the timeout", func() {
+			transition := HibernationTransition{State: HibernationStateWakingUp, Since: now.Add(-10 * time.Minute)}
+			Expect(HibernationTransitionResult(transition, 5*time.Minute, now, errNotReached)).To(Equal(&SingleCheckResult{
+				IsHealthy: false,
+				Detail:    "WakingUp for 10m0s (timeout 5m0s): 2 machines left",
+				Reason:    "WakeUpStuck",
+			}))
+		})
+
+		It("should be unhealthy if the target state is not reached while hibernated", func() {
+			transition := HibernationTransition{State: HibernationStateHibernated, Since: now}
+			Expect(HibernationTransitionResult(transition, 5*time.Minute, now, errNotReached)).To(Equal(&SingleCheckResult{
+				IsHealthy: false,
+				Detail:    "cluster is hibernated, but 2 machines left",
+				Reason:    "HibernationStuck",
+			}))
+		})
+	})
+
+	Describe("#hibernationTransitions", func() {
+		It("should remember when a state was first observed", func() {
+			transitions := newHibernationTransitions()
+
+			Expect(transitions.observe(resource, HibernationStateHibernating, now)).To(Equal(HibernationTransition{State: HibernationStateHibernating, Since: now}))
+			Expect(transitions.observe(resource, HibernationStateHibernating, now.Add(time.Minute))).To(Equal(HibernationTransition{State: HibernationStateHibernating, Since: now}))
+			Expect(transitions.observe(resource, HibernationStateHibernated, now.Add(2*time.Minute))).To(Equal(HibernationTransition{State: HibernationStateHibernated, Since: now.Add(2 * time.Minute)}))
+
+			transitions.forget(resource)
+			Expect(transitions.observe(resource, HibernationStateHibernated, now.Add(3*time.Minute))).To(Equal(HibernationTransition{State: HibernationStateHibernated, Since: now.Add(3 * time.Minute)}))
+		})
+	})
+
+	Describe("#ExecuteHibernationHealthCheckFunctions", func() {
+		It("should only execute the hibernation aware health checks", func() {
+			a := NewActuator("test", "Worker", nil, []ConditionTypeToHealthCheck{
+				{ConditionType: "SystemComponentsHealthy", HealthCheck: &fakeHealthCheck{}},
+				{ConditionType: "EveryNodeReady", HealthCheck: &fakeHibernationHealthCheck{}},
+			}, ExecutionOptions{}).(*Actuator)
+
+			results, err := a.ExecuteHibernationHealthCheckFunctions(context.TODO(), resource, HibernationTransition{State: HibernationStateHibernated, Since: now})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*results).To(ConsistOf(Result{HealthConditionType: "EveryNodeReady", IsHealthy: true, SuccessfulChecks: 1}))
+		})
+	})
+})
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheck
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	checkResultSuccess = "success"
+	checkResultFailure = "failure"
+	checkResultError   = "error"
+)
+
+var (
+	checkDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "gardener_extensions_healthcheck_duration_seconds",
+			Help:    "Duration of a single health check in seconds.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"kind", "type", "condition_type", "check"},
+	)
+
+	checkResults = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gardener_extensions_healthcheck_results_total",
+			Help: "Total number of health check results, partitioned by result (success, failure or error).",
+		},
+		[]string{"kind", "type", "condition_type", "check", "result"},
+	)
+
+	unhealthyResources = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "gardener_extensions_healthcheck_unhealthy_resources",
+			Help: "Number of extension resources with an unhealthy health check condition.",
+		},
+		[]string{"kind", "type", "condition_type"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(checkDuration, checkResults, unhealthyResources)
+}
+
+// checkName returns the name of the given health check used in the metrics, i.e. the name of its type.
+func checkName(check HealthCheck) string {
+	t := reflect.TypeOf(check)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+type unhealthyKey struct {
+	kind          string
+	extensionType string
+	conditionType string
+}
+
+// unhealthyTracker tracks the extension resources with an unhealthy condition and exports their number as gauge.
+type unhealthyTracker struct {
+	lock      sync.Mutex
+	resources map[unhealthyKey]sets.String
+}
+
+// unhealthy tracks the unhealthy extension resources of all health check controllers.
+var unhealthy = newUnhealthyTracker()
+
+func newUnhealthyTracker() *unhealthyTracker {
+	return &unhealthyTracker{resources: map[unhealthyKey]sets.String{}}
+}
+
+// set marks the given resource as healthy or unhealthy for the given condition type.
+func (t *unhealthyTracker) set(kind, extensionType, conditionType string, resource types.NamespacedName, unhealthy bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	key := unhealthyKey{kind: kind, extensionType: extensionType, conditionType: conditionType}
+	if t.resources[key] == nil {
+		t.resources[key] = sets.NewString()
+	}
+	if unhealthy {
+		t.resources[key].Insert(resource.String())
+	} else {
+		t.resources[key].Delete(resource.String())
+	}
+	unhealthyResources.WithLabelValues(kind, extensionType, conditionType).Set(float64(t.resources[key].Len()))
+}
+
+// forget marks the given resource of the given kind as healthy for all condition types, e.g. because it was deleted.
+func (t *unhealthyTracker) forget(kind string, resource types.NamespacedName) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for key, resources := range t.resources {
+		if key.kind == kind && resources.Has(resource.String()) {
+			resources.Delete(resource.String())
+			unhealthyResources.WithLabelValues(key.kind, key.extensionType, key.conditionType).Set(float64(resources.Len()))
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheck
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Metrics", func() {
+	Describe("#checkName", func() {
+		It("should return the name of the type of the health check", func() {
+			Expect(checkName(&fakeHealthCheck{})).To(Equal("fakeHealthCheck"))
+		})
+	})
+
+	Describe("#unhealthyTracker", func() {
+		var (
+			foo = types.NamespacedName{Namespace: "shoot--foo--bar", Name: "foo"}
+			bar = types.NamespacedName{Namespace: "shoot--foo--baz", Name: "bar"}
+			key = unhealthyKey{kind: "Worker", extensionType: "test", conditionType: "EveryNodeReady"}
+		)
+
+		It("should track the unhealthy resources per kind, type and condition type", func() {
+			t := newUnhealthyTracker()
+
+			t.set("Worker", "test", "EveryNodeReady", foo, true)
+			t.set("Worker", "test", "EveryNodeReady", bar, true)
+			t.set("ControlPlane", "test", "ControlPlaneHealthy", foo, true)
+			Expect(t.resources[key].List()).To(ConsistOf(foo.String(), bar.String()))
+
+			t.set("Worker", "test", "EveryNodeReady", bar, false)
+			Expect(t.resources[key].List()).To(ConsistOf(foo.String()))
+
+			t.forget("Worker", foo)
+			Expect(t.resources[key].List()).To(BeEmpty())
+			Expect(t.resources[unhealthyKey{kind: "ControlPlane", extensionType: "test", conditionType: "ControlPlaneHealthy"}].List()).To(ConsistOf(foo.String()))
+		})
+	})
+})
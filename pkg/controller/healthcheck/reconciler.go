@@ -17,9 +17,11 @@ package healthcheck
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/gardener/gardener-extensions/pkg/controller"
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	healthcheckconfig "github.com/gardener/gardener-extensions/pkg/controller/healthcheck/config"
 	"github.com/gardener/gardener-extensions/pkg/util"
 
 	"github.com/gardener/gardener/pkg/api/extensions"
@@ -32,7 +34,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -50,6 +52,8 @@ type reconciler struct {
 	recorder            record.EventRecorder
 	registeredExtension RegisteredExtension
 	syncPeriod          metav1.Duration
+	history             *conditionHistory
+	transitions         *hibernationTransitions
 }
 
 const (
@@ -58,17 +62,24 @@ const (
 
 	// HealthCheckSuccessful is the reason phrase for the health check condition if all tests are successful.
 	HealthCheckSuccessful = "HealthCheckSuccessful"
+
+	// HealthCheckProgressing is the reason phrase for the health check condition if the result of the tests changed,
+	// but the configured threshold for changing the condition status is not yet reached.
+	HealthCheckProgressing = "HealthCheckProgressing"
 )
 
 // NewReconciler creates a new performHealthCheck.Reconciler that reconciles
 // the registered extension resources (Gardener's `extensions.gardener.cloud` API group).
-func NewReconciler(mgr manager.Manager, actuator HealthCheckActuator, registeredExtension RegisteredExtension, syncPeriod metav1.Duration) reconcile.Reconciler {
+// The given thresholds configure how many consecutive check runs are required before a condition changes its status.
+func NewReconciler(mgr manager.Manager, actuator HealthCheckActuator, registeredExtension RegisteredExtension, syncPeriod metav1.Duration, thresholds *healthcheckconfig.HealthCheckThresholds) reconcile.Reconciler {
 	return &reconciler{
 		logger:              log.Log.WithName(ControllerName),
 		actuator:            actuator,
 		recorder:            mgr.GetEventRecorderFor(ControllerName),
 		registeredExtension: registeredExtension,
 		syncPeriod:          syncPeriod,
+		history:             newConditionHistory(thresholds),
+		transitions:         newHibernationTransitions(),
 	}
 }
 
@@ -92,6 +103,9 @@ func (r *reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 
 	if err := r.client.Get(r.ctx, request.NamespacedName, &rawExtension); err != nil {
 		if errors.IsNotFound(err) {
+			r.history.forget(request.NamespacedName)
+			r.transitions.forget(request.NamespacedName)
+			unhealthy.forget(r.registeredExtension.groupVersionKind.Kind, request.NamespacedName)
 			return r.resultWithRequeue(), nil
 		}
 		return r.resultWithRequeue(), err
@@ -103,6 +117,9 @@ func (r *reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 	}
 
 	if acc.GetDeletionTimestamp() != nil {
+		r.history.forget(request.NamespacedName)
+		r.transitions.forget(request.NamespacedName)
+		unhealthy.forget(r.registeredExtension.groupVersionKind.Kind, request.NamespacedName)
 		r.logger.Info("Do not perform HealthCheck for extension resource. Extension is being deleted.", "name", acc.GetName(), "namespace", acc.GetNamespace())
 		return reconcile.Result{}, nil
 	}
@@ -116,25 +133,131 @@ func (r *reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 	if err != nil {
 		return reconcile.Result{}, err
 	}
+	state := GetHibernationState(cluster)
+	if state == HibernationStateAwake {
+		r.transitions.forget(request.NamespacedName)
+		return r.performAwakeHealthCheck(r.ctx, request, acc, rawExtension, func(ctx context.Context) (*[]Result, error) {
+			return r.actuator.ExecuteHealthCheckFunctions(ctx, request.NamespacedName)
+		})
+	}
+
+	if hibernationActuator, ok := r.actuator.(HibernationHealthCheckActuator); ok {
+		transition := r.transitions.observe(request.NamespacedName, state, time.Now())
+		return r.performHibernationHealthCheck(r.ctx, request, acc, rawExtension, hibernationActuator, transition)
+	}
+
 	if controller.IsHibernated(cluster) {
-		for _, healthConditionType := range r.registeredExtension.healthConditionType {
-			healthCondition := gardencorev1beta1helper.GetOrInitCondition(r.registeredExtension.extension.GetExtensionStatus().GetConditions(), gardencorev1beta1.ConditionType(healthConditionType))
-			if err := r.updateExtensionConditionHibernated(r.ctx, r.registeredExtension.extension, &rawExtension, healthCondition); err != nil {
-				return r.resultWithRequeue(), err
-			}
+		unhealthy.forget(r.registeredExtension.groupVersionKind.Kind, request.NamespacedName)
+		if err := r.updateExtensionConditionsHibernated(r.ctx, &rawExtension, r.registeredExtension.healthConditionType); err != nil {
+			return r.resultWithRequeue(), err
 		}
 		return reconcile.Result{}, err
 	}
 
-	return r.performHealthCheck(r.ctx, request, rawExtension)
+	return r.performHealthCheck(r.ctx, request, acc, rawExtension, func(ctx context.Context) (*[]Result, error) {
+		return r.actuator.ExecuteHealthCheckFunctions(ctx, request.NamespacedName)
+	})
+}
+
+// performHibernationHealthCheck executes the hibernation aware health checks while the cluster is hibernating,
+// hibernated or waking up. The conditions without hibernation aware health checks are set to hibernated, or, while
+// waking up, are not updated until the cluster is awake. If there are no hibernation aware health checks at all, the
+// regular health checks are executed while waking up.
+func (r *reconciler) performHibernationHealthCheck(ctx context.Context, request reconcile.Request, acc extensionsv1alpha1.Object, rawExtension unstructured.Unstructured, actuator HibernationHealthCheckActuator, transition HibernationTransition) (reconcile.Result, error) {
+	var checkedConditionTypes = sets.NewString()
+
+	result, err := r.performHealthCheck(ctx, request, acc, rawExtension, func(ctx context.Context) (*[]Result, error) {
+		results, err := actuator.ExecuteHibernationHealthCheckFunctions(ctx, request.NamespacedName, transition)
+		if results != nil {
+			for _, result := range *results {
+				checkedConditionTypes.Insert(result.HealthConditionType)
+			}
+		}
+		return results, err
+	})
+	if err != nil {
+		return result, err
+	}
+	if transition.State == HibernationStateWakingUp {
+		if checkedConditionTypes.Len() == 0 {
+			return r.performHealthCheck(ctx, request, acc, rawExtension, func(ctx context.Context) (*[]Result, error) {
+				return r.actuator.ExecuteHealthCheckFunctions(ctx, request.NamespacedName)
+			})
+		}
+		return result, nil
+	}
+
+	var uncheckedConditionTypes []string
+	for _, healthConditionType := range r.registeredExtension.healthConditionType {
+		if !checkedConditionTypes.Has(healthConditionType) {
+			unhealthy.set(r.registeredExtension.groupVersionKind.Kind, acc.GetExtensionSpec().GetExtensionType(), healthConditionType, request.NamespacedName, false)
+			uncheckedConditionTypes = append(uncheckedConditionTypes, healthConditionType)
+		}
+	}
+	if err := r.updateExtensionConditionsHibernated(ctx, &rawExtension, uncheckedConditionTypes); err != nil {
+		return r.resultWithRequeue(), err
+	}
+	return result, nil
+}
+
+// performAwakeHealthCheck executes the health checks while the cluster is awake. The conditions which only have
+// hibernation aware health checks are set to awake, so that they do not keep the result of the last hibernation.
+func (r *reconciler) performAwakeHealthCheck(ctx context.Context, request reconcile.Request, acc extensionsv1alpha1.Object, rawExtension unstructured.Unstructured, execute func(context.Context) (*[]Result, error)) (reconcile.Result, error) {
+	var (
+		executed              bool
+		checkedConditionTypes = sets.NewString()
+	)
+
+	result, err := r.performHealthCheck(ctx, request, acc, rawExtension, func(ctx context.Context) (*[]Result, error) {
+		results, err := execute(ctx)
+		if err == nil && results != nil {
+			executed = true
+			for _, result := range *results {
+				checkedConditionTypes.Insert(result.HealthConditionType)
+			}
+		}
+		return results, err
+	})
+	if err != nil || !executed {
+		return result, err
+	}
+
+	var uncheckedConditionTypes []string
+	for _, healthConditionType := range r.registeredExtension.healthConditionType {
+		if !checkedConditionTypes.Has(healthConditionType) {
+			unhealthy.set(r.registeredExtension.groupVersionKind.Kind, acc.GetExtensionSpec().GetExtensionType(), healthConditionType, request.NamespacedName, false)
+			uncheckedConditionTypes = append(uncheckedConditionTypes, healthConditionType)
+		}
+	}
+	if err := r.updateExtensionConditionsAwake(ctx, &rawExtension, uncheckedConditionTypes); err != nil {
+		return r.resultWithRequeue(), err
+	}
+	return result, nil
 }
 
-func (r *reconciler) performHealthCheck(ctx context.Context, request reconcile.Request, rawExtension unstructured.Unstructured) (reconcile.Result, error) {
-	healthCheckResults, err := r.actuator.ExecuteHealthCheckFunctions(ctx, types.NamespacedName{Namespace: request.Namespace, Name: request.Name})
+func (r *reconciler) performHealthCheck(ctx context.Context, request reconcile.Request, acc extensionsv1alpha1.Object, rawExtension unstructured.Unstructured, execute func(context.Context) (*[]Result, error)) (reconcile.Result, error) {
+	var (
+		kind          = r.registeredExtension.groupVersionKind.Kind
+		extensionType = acc.GetExtensionSpec().GetExtensionType()
+	)
+
+	healthCheckResults, err := execute(ctx)
 	if err != nil {
 		r.logger.Info("Failed to execute healthChecks. Updating each HealthCheckCondition for the extension resource to ConditionCheckError.", "kind", r.registeredExtension.groupVersionKind.Kind, "health condition type", r.registeredExtension.healthConditionType, "name", request.Name, "namespace", request.Namespace, "error", err.Error())
 		for _, healthConditionType := range r.registeredExtension.healthConditionType {
 			healthCondition := gardencorev1beta1helper.GetOrInitCondition(r.registeredExtension.extension.GetExtensionStatus().GetConditions(), gardencorev1beta1.ConditionType(healthConditionType))
+
+			// health checks that could not be executed count as unsuccessful check runs for the thresholds
+			status, progress := r.history.record(historyKey{resource: request.NamespacedName, conditionType: healthConditionType}, false, currentConditionStatus(acc, healthConditionType), time.Now())
+			if status == gardencorev1beta1.ConditionProgressing {
+				message := fmt.Sprintf("%s Failed to execute health checks for '%s': %v", progress, kind, err)
+				if err := r.updateExtensionConditionToProgressingWithMessage(ctx, r.registeredExtension.extension, &rawExtension, healthCondition, message); err != nil {
+					return r.resultWithRequeue(), err
+				}
+				continue
+			}
+
+			unhealthy.set(kind, extensionType, healthConditionType, request.NamespacedName, true)
 			if err := r.updateExtensionConditionFailedToExecute(ctx, r.registeredExtension.extension, &rawExtension, healthCondition, r.registeredExtension.groupVersionKind.Kind, err); err != nil {
 				return r.resultWithRequeue(), err
 			}
@@ -145,16 +268,28 @@ func (r *reconciler) performHealthCheck(ctx context.Context, request reconcile.R
 	for _, healthCheckResult := range *healthCheckResults {
 		// get or init conditions on extension resource
 		healthCondition := gardencorev1beta1helper.GetOrInitCondition(r.registeredExtension.extension.GetExtensionStatus().GetConditions(), gardencorev1beta1.ConditionType(healthCheckResult.HealthConditionType))
-		if !healthCheckResult.IsHealthy && healthCheckResult.FailedChecks > 0 {
+
+		status, progress := r.history.record(historyKey{resource: request.NamespacedName, conditionType: healthCheckResult.HealthConditionType}, healthCheckResult.IsHealthy, currentConditionStatus(acc, healthCheckResult.HealthConditionType), time.Now())
+		if !healthCheckResult.IsHealthy && healthCheckResult.FailedChecks > 0 && status != gardencorev1beta1.ConditionProgressing {
 			r.logger.Info("Updating HealthCheckCondition for extension resource to ConditionCheckError.", "kind", r.registeredExtension.groupVersionKind.Kind, "health condition type", healthCheckResult.HealthConditionType, "name", request.Name, "namespace", request.Namespace)
+			unhealthy.set(kind, extensionType, healthCheckResult.HealthConditionType, request.NamespacedName, true)
 			if err := r.updateExtensionConditionToConditionCheckError(ctx, r.registeredExtension.extension, &rawExtension, healthCondition, r.registeredExtension.groupVersionKind.Kind, healthCheckResult); err != nil {
 				return r.resultWithRequeue(), err
 			}
 			continue
 		}
 
+		if status == gardencorev1beta1.ConditionProgressing {
+			r.logger.Info("Health check result for extension resource changed, but threshold not yet reached.", "kind", r.registeredExtension.groupVersionKind.Kind, "health condition type", healthCheckResult.HealthConditionType, "name", request.Name, "namespace", request.Namespace, "healthy", healthCheckResult.IsHealthy, "progress", progress)
+			if err := r.updateExtensionConditionToProgressing(ctx, r.registeredExtension.extension, &rawExtension, healthCondition, healthCheckResult, progress); err != nil {
+				return r.resultWithRequeue(), err
+			}
+			continue
+		}
+
 		if !healthCheckResult.IsHealthy {
 			r.logger.Info("Health check for extension resource unsuccessful.", "kind", fmt.Sprintf("%s.%s.%s", r.registeredExtension.groupVersionKind.Kind, r.registeredExtension.groupVersionKind.Group, r.registeredExtension.groupVersionKind.Version), "name", request.Name, "namespace", request.Namespace, "failed", healthCheckResult.FailedChecks, "successful", healthCheckResult.SuccessfulChecks, "details", healthCheckResult.GetDetails())
+			unhealthy.set(kind, extensionType, healthCheckResult.HealthConditionType, request.NamespacedName, true)
 			if err := r.updateExtensionConditionToError(ctx, r.registeredExtension.extension, &rawExtension, healthCondition, healthCheckResult); err != nil {
 				return r.resultWithRequeue(), err
 			}
@@ -162,6 +297,7 @@ func (r *reconciler) performHealthCheck(ctx context.Context, request reconcile.R
 		}
 
 		r.logger.V(6).Info("Health check for extension resource successful.", "kind", r.registeredExtension.groupVersionKind.Kind, "health condition type", healthCheckResult.HealthConditionType, "name", request.Name, "namespace", request.Namespace)
+		unhealthy.set(kind, extensionType, healthCheckResult.HealthConditionType, request.NamespacedName, false)
 		if err := r.updateExtensionConditionToSuccessful(ctx, r.registeredExtension.extension, &rawExtension, healthCondition, healthCheckResult); err != nil {
 			return r.resultWithRequeue(), err
 		}
@@ -190,11 +326,47 @@ func (r *reconciler) updateExtensionConditionToSuccessful(ctx context.Context, e
 	return r.updateExtensionCondition(ctx, extension, condition, extensionResource, healthCondition)
 }
 
+func (r *reconciler) updateExtensionConditionToProgressing(ctx context.Context, extensionResource extensionsv1alpha1.Object, extension *unstructured.Unstructured, condition gardencorev1beta1.Condition, healthCheckResult Result, progress string) error {
+	message := progress
+	if healthCheckResult.FailedChecks > 0 {
+		message = fmt.Sprintf("%s Failed to execute %d/%d health checks. %s", progress, healthCheckResult.FailedChecks, healthCheckResult.SuccessfulChecks+healthCheckResult.UnsuccessfulChecks+healthCheckResult.FailedChecks, healthCheckResult.GetDetails())
+	} else if !healthCheckResult.IsHealthy {
+		message = fmt.Sprintf("%s Health check for %d/%d component(s) unsuccessful. %s", progress, healthCheckResult.UnsuccessfulChecks, healthCheckResult.UnsuccessfulChecks+healthCheckResult.SuccessfulChecks, healthCheckResult.GetDetails())
+	}
+	return r.updateExtensionConditionToProgressingWithMessage(ctx, extensionResource, extension, condition, message)
+}
+
+func (r *reconciler) updateExtensionConditionToProgressingWithMessage(ctx context.Context, extensionResource extensionsv1alpha1.Object, extension *unstructured.Unstructured, condition gardencorev1beta1.Condition, message string) error {
+	healthCondition := gardencorev1beta1helper.UpdatedCondition(condition, gardencorev1beta1.ConditionProgressing, HealthCheckProgressing, message)
+	return r.updateExtensionCondition(ctx, extension, condition, extensionResource, healthCondition)
+}
+
+func (r *reconciler) updateExtensionConditionsHibernated(ctx context.Context, extension *unstructured.Unstructured, healthConditionTypes []string) error {
+	for _, healthConditionType := range healthConditionTypes {
+		healthCondition := gardencorev1beta1helper.GetOrInitCondition(r.registeredExtension.extension.GetExtensionStatus().GetConditions(), gardencorev1beta1.ConditionType(healthConditionType))
+		if err := r.updateExtensionConditionHibernated(ctx, r.registeredExtension.extension, extension, healthCondition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *reconciler) updateExtensionConditionHibernated(ctx context.Context, extensionResource extensionsv1alpha1.Object, extension *unstructured.Unstructured, condition gardencorev1beta1.Condition) error {
 	healthCondition := gardencorev1beta1helper.UpdatedCondition(condition, gardencorev1beta1.ConditionTrue, "HealthCheckSuccessful", fmt.Sprintf("Shoot is hibernated"))
 	return r.updateExtensionCondition(ctx, extension, condition, extensionResource, healthCondition)
 }
 
+func (r *reconciler) updateExtensionConditionsAwake(ctx context.Context, extension *unstructured.Unstructured, healthConditionTypes []string) error {
+	for _, healthConditionType := range healthConditionTypes {
+		condition := gardencorev1beta1helper.GetOrInitCondition(r.registeredExtension.extension.GetExtensionStatus().GetConditions(), gardencorev1beta1.ConditionType(healthConditionType))
+		healthCondition := gardencorev1beta1helper.UpdatedCondition(condition, gardencorev1beta1.ConditionTrue, HealthCheckSuccessful, "Shoot is awake, the health checks are only executed during hibernation")
+		if err := r.updateExtensionCondition(ctx, extension, condition, r.registeredExtension.extension, healthCondition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *reconciler) updateExtensionCondition(ctx context.Context, extension *unstructured.Unstructured, condition gardencorev1beta1.Condition, extensionResource extensionsv1alpha1.Object, healthCondition gardencorev1beta1.Condition) error {
 	return extensionscontroller.TryUpdateStatus(ctx, retry.DefaultBackoff, r.client, extension, func() error {
 		acc, err := extensions.Accessor(extension.DeepCopyObject())
@@ -216,6 +388,15 @@ func (r *reconciler) resultWithRequeue() reconcile.Result {
 	return reconcile.Result{RequeueAfter: r.syncPeriod.Duration}
 }
 
+func currentConditionStatus(acc extensionsv1alpha1.Object, conditionType string) gardencorev1beta1.ConditionStatus {
+	if status := acc.GetExtensionStatus(); status != nil {
+		if condition := gardencorev1beta1helper.GetCondition(status.GetConditions(), gardencorev1beta1.ConditionType(conditionType)); condition != nil {
+			return condition.Status
+		}
+	}
+	return gardencorev1beta1.ConditionUnknown
+}
+
 func isInMigration(accessor extensionsv1alpha1.Object) bool {
 	annotations := accessor.GetAnnotations()
 	if annotations != nil &&
@@ -0,0 +1,137 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+
+	healthcheckconfig "github.com/gardener/gardener-extensions/pkg/controller/healthcheck/config"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardencorev1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Reconciler", func() {
+	const conditionType = "SystemComponentsHealthy"
+
+	var (
+		ctx     = context.TODO()
+		c       client.Client
+		r       *reconciler
+		request = reconcile.Request{NamespacedName: client.ObjectKey{Namespace: "shoot--foo--bar", Name: "foo"}}
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(extensionsv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		worker := &extensionsv1alpha1.Worker{
+			ObjectMeta: metav1.ObjectMeta{Namespace: request.Namespace, Name: request.Name},
+			Spec:       extensionsv1alpha1.WorkerSpec{DefaultSpec: extensionsv1alpha1.DefaultSpec{Type: "test"}},
+		}
+		c = fakeclient.NewFakeClientWithScheme(scheme, worker)
+
+		r = &reconciler{
+			logger: log.Log.WithName("test"),
+			client: c,
+			registeredExtension: RegisteredExtension{
+				extension:           worker,
+				healthConditionType: []string{conditionType},
+				groupVersionKind:    extensionsv1alpha1.SchemeGroupVersion.WithKind(extensionsv1alpha1.WorkerResource),
+			},
+			history:     newConditionHistory(&healthcheckconfig.HealthCheckThresholds{FailureThreshold: 2}),
+			transitions: newHibernationTransitions(),
+		}
+	})
+
+	performHealthCheck := func(execute func(context.Context) (*[]Result, error)) *gardencorev1beta1.Condition {
+		rawExtension := unstructured.Unstructured{}
+		rawExtension.SetGroupVersionKind(r.registeredExtension.groupVersionKind)
+		Expect(c.Get(ctx, request.NamespacedName, &rawExtension)).To(Succeed())
+
+		worker := &extensionsv1alpha1.Worker{}
+		Expect(c.Get(ctx, request.NamespacedName, worker)).To(Succeed())
+
+		_, err := r.performHealthCheck(ctx, request, worker, rawExtension, execute)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(c.Get(ctx, request.NamespacedName, worker)).To(Succeed())
+		return gardencorev1beta1helper.GetCondition(worker.Status.Conditions, conditionType)
+	}
+
+	It("should update the conditions with only hibernation aware health checks while the cluster is awake", func() {
+		const hibernationConditionType = "HibernationHealthy"
+		r.registeredExtension.healthConditionType = []string{conditionType, hibernationConditionType}
+
+		worker := &extensionsv1alpha1.Worker{}
+		Expect(c.Get(ctx, request.NamespacedName, worker)).To(Succeed())
+		worker.Status.Conditions = []gardencorev1beta1.Condition{{Type: hibernationConditionType, Status: gardencorev1beta1.ConditionFalse, Reason: "HibernationStuck"}}
+		Expect(c.Status().Update(ctx, worker)).To(Succeed())
+
+		rawExtension := unstructured.Unstructured{}
+		rawExtension.SetGroupVersionKind(r.registeredExtension.groupVersionKind)
+		Expect(c.Get(ctx, request.NamespacedName, &rawExtension)).To(Succeed())
+
+		_, err := r.performAwakeHealthCheck(ctx, request, worker, rawExtension, func(context.Context) (*[]Result, error) {
+			return &[]Result{{HealthConditionType: conditionType, IsHealthy: true, SuccessfulChecks: 1}}, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(c.Get(ctx, request.NamespacedName, worker)).To(Succeed())
+		condition := gardencorev1beta1helper.GetCondition(worker.Status.Conditions, hibernationConditionType)
+		Expect(condition.Status).To(Equal(gardencorev1beta1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(HealthCheckSuccessful))
+		Expect(gardencorev1beta1helper.GetCondition(worker.Status.Conditions, conditionType).Status).To(Equal(gardencorev1beta1.ConditionTrue))
+	})
+
+	It("should respect the failure threshold if the health checks could not be executed", func() {
+		execute := func(context.Context) (*[]Result, error) {
+			return nil, fmt.Errorf("shoot not reachable")
+		}
+
+		condition := performHealthCheck(execute)
+		Expect(condition.Status).To(Equal(gardencorev1beta1.ConditionProgressing))
+		Expect(condition.Reason).To(Equal(HealthCheckProgressing))
+
+		condition = performHealthCheck(execute)
+		Expect(condition.Status).To(Equal(gardencorev1beta1.ConditionUnknown))
+		Expect(condition.Reason).To(Equal(gardencorev1beta1.ConditionCheckError))
+	})
+
+	It("should respect the failure threshold if single health checks could not be executed", func() {
+		execute := func(context.Context) (*[]Result, error) {
+			return &[]Result{{HealthConditionType: conditionType, FailedChecks: 1, SuccessfulChecks: 1}}, nil
+		}
+
+		condition := performHealthCheck(execute)
+		Expect(condition.Status).To(Equal(gardencorev1beta1.ConditionProgressing))
+		Expect(condition.Reason).To(Equal(HealthCheckProgressing))
+
+		condition = performHealthCheck(execute)
+		Expect(condition.Status).To(Equal(gardencorev1beta1.ConditionUnknown))
+		Expect(condition.Reason).To(Equal(gardencorev1beta1.ConditionCheckError))
+	})
+})
@@ -0,0 +1,118 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheck
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	healthcheckconfig "github.com/gardener/gardener-extensions/pkg/controller/healthcheck/config"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// historyKey identifies the history of a single condition type of an extension resource.
+type historyKey struct {
+	resource      types.NamespacedName
+	conditionType string
+}
+
+// historyEntry is the history of the check runs of a single condition type of an extension resource.
+type historyEntry struct {
+	consecutiveFailures  int32
+	consecutiveSuccesses int32
+	firstFailure         time.Time
+	// unhealthy is true if the condition was set to 'False' and did not yet recover.
+	unhealthy bool
+}
+
+// conditionHistory tracks the consecutive check runs per extension resource and condition type, and decides on the
+// status of the conditions according to the configured thresholds.
+type conditionHistory struct {
+	thresholds healthcheckconfig.HealthCheckThresholds
+
+	lock    sync.Mutex
+	entries map[historyKey]*historyEntry
+}
+
+func newConditionHistory(thresholds *healthcheckconfig.HealthCheckThresholds) *conditionHistory {
+	h := &conditionHistory{entries: map[historyKey]*historyEntry{}}
+	if thresholds != nil {
+		h.thresholds = *thresholds
+	}
+	if h.thresholds.FailureThreshold < 1 {
+		h.thresholds.FailureThreshold = 1
+	}
+	if h.thresholds.SuccessThreshold < 1 {
+		h.thresholds.SuccessThreshold = 1
+	}
+	return h
+}
+
+// record records the result of a check run and returns the status of the condition, given its current status.
+// The returned message describes the progress towards the threshold if the condition is 'Progressing'.
+func (h *conditionHistory) record(key historyKey, isHealthy bool, current gardencorev1beta1.ConditionStatus, now time.Time) (gardencorev1beta1.ConditionStatus, string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	entry, ok := h.entries[key]
+	if !ok {
+		entry = &historyEntry{}
+		h.entries[key] = entry
+	}
+
+	if isHealthy {
+		entry.consecutiveFailures = 0
+		entry.firstFailure = time.Time{}
+		entry.consecutiveSuccesses++
+
+		if (!entry.unhealthy && current != gardencorev1beta1.ConditionFalse) || entry.consecutiveSuccesses >= h.thresholds.SuccessThreshold {
+			entry.unhealthy = false
+			return gardencorev1beta1.ConditionTrue, ""
+		}
+		entry.unhealthy = true
+		return gardencorev1beta1.ConditionProgressing, fmt.Sprintf("Health checks successful in %d/%d consecutive runs.", entry.consecutiveSuccesses, h.thresholds.SuccessThreshold)
+	}
+
+	entry.consecutiveSuccesses = 0
+	if entry.consecutiveFailures == 0 {
+		entry.firstFailure = now
+	}
+	entry.consecutiveFailures++
+
+	if entry.unhealthy || current == gardencorev1beta1.ConditionFalse || entry.consecutiveFailures >= h.thresholds.FailureThreshold {
+		entry.unhealthy = true
+		return gardencorev1beta1.ConditionFalse, ""
+	}
+	if gracePeriod := h.thresholds.ProgressingGracePeriod; gracePeriod != nil && now.Sub(entry.firstFailure) >= gracePeriod.Duration {
+		entry.unhealthy = true
+		return gardencorev1beta1.ConditionFalse, ""
+	}
+	return gardencorev1beta1.ConditionProgressing, fmt.Sprintf("Health checks unsuccessful in %d/%d consecutive runs.", entry.consecutiveFailures, h.thresholds.FailureThreshold)
+}
+
+// forget removes the history of all condition types of the given extension resource.
+func (h *conditionHistory) forget(resource types.NamespacedName) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for key := range h.entries {
+		if key.resource == resource {
+			delete(h.entries, key)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheck
+
+import (
+	"time"
+
+	healthcheckconfig "github.com/gardener/gardener-extensions/pkg/controller/healthcheck/config"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Thresholds", func() {
+	var (
+		key = historyKey{resource: types.NamespacedName{Namespace: "shoot--foo--bar", Name: "foo"}, conditionType: "ControlPlaneHealthy"}
+		now = time.Now()
+
+		record = func(h *conditionHistory, isHealthy bool, current gardencorev1beta1.ConditionStatus) gardencorev1beta1.ConditionStatus {
+			status, _ := h.record(key, isHealthy, current, now)
+			return status
+		}
+	)
+
+	It("should change the condition status with every check run if no thresholds are configured", func() {
+		h := newConditionHistory(nil)
+
+		Expect(record(h, false, gardencorev1beta1.ConditionTrue)).To(Equal(gardencorev1beta1.ConditionFalse))
+		Expect(record(h, true, gardencorev1beta1.ConditionFalse)).To(Equal(gardencorev1beta1.ConditionTrue))
+	})
+
+	It("should respect the failure and success thresholds", func() {
+		h := newConditionHistory(&healthcheckconfig.HealthCheckThresholds{FailureThreshold: 3, SuccessThreshold: 2})
+
+		Expect(record(h, true, gardencorev1beta1.ConditionUnknown)).To(Equal(gardencorev1beta1.ConditionTrue))
+		Expect(record(h, false, gardencorev1beta1.ConditionTrue)).To(Equal(gardencorev1beta1.ConditionProgressing))
+		Expect(record(h, false, gardencorev1beta1.ConditionProgressing)).To(Equal(gardencorev1beta1.ConditionProgressing))
+		Expect(record(h, false, gardencorev1beta1.ConditionProgressing)).To(Equal(gardencorev1beta1.ConditionFalse))
+		Expect(record(h, true, gardencorev1beta1.ConditionFalse)).To(Equal(gardencorev1beta1.ConditionProgressing))
+		Expect(record(h, true, gardencorev1beta1.ConditionProgressing)).To(Equal(gardencorev1beta1.ConditionTrue))
+	})
+
+	It("should return to True immediately if a failure did not reach the threshold", func() {
+		h := newConditionHistory(&healthcheckconfig.HealthCheckThresholds{FailureThreshold: 3, SuccessThreshold: 2})
+
+		Expect(record(h, false, gardencorev1beta1.ConditionTrue)).To(Equal(gardencorev1beta1.ConditionProgressing))
+		Expect(record(h, true, gardencorev1beta1.ConditionProgressing)).To(Equal(gardencorev1beta1.ConditionTrue))
+	})
+
+	It("should set the condition to False after the grace period", func() {
+		h := newConditionHistory(&healthcheckconfig.HealthCheckThresholds{FailureThreshold: 10, ProgressingGracePeriod: &metav1.Duration{Duration: time.Minute}})
+
+		status, _ := h.record(key, false, gardencorev1beta1.ConditionTrue, now)
+		Expect(status).To(Equal(gardencorev1beta1.ConditionProgressing))
+		status, _ = h.record(key, false, gardencorev1beta1.ConditionProgressing, now.Add(2*time.Minute))
+		Expect(status).To(Equal(gardencorev1beta1.ConditionFalse))
+	})
+
+	It("should forget the history of a resource", func() {
+		h := newConditionHistory(&healthcheckconfig.HealthCheckThresholds{FailureThreshold: 2})
+
+		Expect(record(h, false, gardencorev1beta1.ConditionTrue)).To(Equal(gardencorev1beta1.ConditionProgressing))
+		h.forget(key.resource)
+		Expect(record(h, false, gardencorev1beta1.ConditionTrue)).To(Equal(gardencorev1beta1.ConditionProgressing))
+	})
+})
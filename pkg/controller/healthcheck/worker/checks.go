@@ -0,0 +1,35 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"github.com/gardener/gardener-extensions/pkg/controller/healthcheck"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+)
+
+// EveryNodeReadyHealthChecks returns the worker health checks which contribute to the EveryNodeReady condition:
+// sufficient nodes, node pressure, node readiness (with the DefaultNodeNotReadyGracePeriod), kubelet version skew
+// (with the DefaultMaxKubeletMinorVersionSkew) and orphan nodes (with the DefaultOrphanNodeGracePeriod).
+func EveryNodeReadyHealthChecks() []healthcheck.ConditionTypeToHealthCheck {
+	conditionType := string(gardencorev1beta1.ShootEveryNodeReady)
+	return []healthcheck.ConditionTypeToHealthCheck{
+		{ConditionType: conditionType, HealthCheck: NewSufficientNodesChecker()},
+		{ConditionType: conditionType, HealthCheck: NewNodePressureChecker()},
+		{ConditionType: conditionType, HealthCheck: NewNodeReadinessChecker(DefaultNodeNotReadyGracePeriod)},
+		{ConditionType: conditionType, HealthCheck: NewKubeletVersionSkewChecker(DefaultMaxKubeletMinorVersionSkew)},
+		{ConditionType: conditionType, HealthCheck: NewOrphanNodesChecker(DefaultOrphanNodeGracePeriod)},
+	}
+}
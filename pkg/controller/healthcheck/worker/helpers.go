@@ -16,9 +16,13 @@ package worker
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/gardener/gardener-extensions/pkg/controller/healthcheck/general"
 
+	"github.com/Masterminds/semver"
 	machinev1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -36,6 +40,12 @@ var (
 		machinev1alpha1.MachineDeploymentReplicaFailure,
 		machinev1alpha1.MachineDeploymentFrozen,
 	}
+
+	nodePressureConditionTypes = []corev1.NodeConditionType{
+		corev1.NodeMemoryPressure,
+		corev1.NodeDiskPressure,
+		corev1.NodePIDPressure,
+	}
 )
 
 func checkSufficientNodesAvailable(nodeList *corev1.NodeList, machineDeploymentList *machinev1alpha1.MachineDeploymentList) (bool, *string, error) {
@@ -126,3 +136,150 @@ func getMachineDeploymentCondition(conditions []machinev1alpha1.MachineDeploymen
 func requiredConditionMissing(conditionType string) error {
 	return fmt.Errorf("condition %q is missing", conditionType)
 }
+
+func checkNodesWithoutPressure(nodes []corev1.Node) (bool, *string, error) {
+	var details []string
+	for _, node := range nodes {
+		var pressures []string
+		for _, conditionType := range nodePressureConditionTypes {
+			if condition := getNodeCondition(node.Status.Conditions, conditionType); condition != nil && condition.Status == corev1.ConditionTrue {
+				pressures = append(pressures, string(conditionType))
+			}
+		}
+		if len(pressures) > 0 {
+			details = append(details, fmt.Sprintf("%s (%s)", node.Name, strings.Join(pressures, ", ")))
+		}
+	}
+
+	if len(details) > 0 {
+		reason := "NodesUnderPressure"
+		sort.Strings(details)
+		err := fmt.Errorf("%d worker node(s) under pressure: %s", len(details), strings.Join(details, "; "))
+		return false, &reason, err
+	}
+	return true, nil, nil
+}
+
+func checkNodesReady(nodes []corev1.Node, now time.Time, gracePeriod time.Duration) (bool, *string, error) {
+	var details []string
+	for _, node := range nodes {
+		condition := getNodeCondition(node.Status.Conditions, corev1.NodeReady)
+		if condition != nil && condition.Status == corev1.ConditionTrue {
+			continue
+		}
+
+		since := node.CreationTimestamp.Time
+		status := "no Ready condition"
+		if condition != nil {
+			since = condition.LastTransitionTime.Time
+			status = fmt.Sprintf("Ready=%s", condition.Status)
+			if condition.Reason != "" {
+				status = fmt.Sprintf("%s, reason %q", status, condition.Reason)
+			}
+		}
+		if notReadyFor := now.Sub(since); notReadyFor > gracePeriod {
+			details = append(details, fmt.Sprintf("%s (%s for %s)", node.Name, status, notReadyFor.Round(time.Second)))
+		}
+	}
+
+	if len(details) > 0 {
+		reason := "NodesNotReady"
+		sort.Strings(details)
+		err := fmt.Errorf("%d worker node(s) not ready for longer than %s: %s", len(details), gracePeriod, strings.Join(details, "; "))
+		return false, &reason, err
+	}
+	return true, nil, nil
+}
+
+func checkKubeletVersionSkew(nodes []corev1.Node, cpVersion *semver.Version, maxMinorSkew int64) (bool, *string, error) {
+	var details []string
+	for _, node := range nodes {
+		kubeletVersion, err := semver.NewVersion(node.Status.NodeInfo.KubeletVersion)
+		if err != nil {
+			details = append(details, fmt.Sprintf("%s (unparseable kubelet version %q)", node.Name, node.Status.NodeInfo.KubeletVersion))
+			continue
+		}
+
+		switch {
+		case kubeletVersion.Major() != cpVersion.Major() || kubeletVersion.Minor() > cpVersion.Minor():
+			details = append(details, fmt.Sprintf("%s (kubelet %s is newer than control plane)", node.Name, kubeletVersion.Original()))
+		case cpVersion.Minor()-kubeletVersion.Minor() > maxMinorSkew:
+			details = append(details, fmt.Sprintf("%s (kubelet %s is more than %d minor versions older than control plane)", node.Name, kubeletVersion.Original(), maxMinorSkew))
+		}
+	}
+
+	if len(details) > 0 {
+		reason := "KubeletVersionSkew"
+		sort.Strings(details)
+		err := fmt.Errorf("%d worker node(s) outside of the allowed kubelet version skew to control plane version %s: %s", len(details), cpVersion.Original(), strings.Join(details, "; "))
+		return false, &reason, err
+	}
+	return true, nil, nil
+}
+
+func checkNoOrphanNodes(nodes []corev1.Node, machines []machinev1alpha1.Machine, now time.Time, gracePeriod time.Duration) (bool, *string, error) {
+	machineNodes := make(map[string]struct{}, len(machines))
+	for _, machine := range machines {
+		if machine.Status.Node != "" {
+			machineNodes[machine.Status.Node] = struct{}{}
+		}
+	}
+
+	var orphans []string
+	for _, node := range nodes {
+		if _, ok := machineNodes[node.Name]; !ok && now.Sub(node.CreationTimestamp.Time) > gracePeriod {
+			orphans = append(orphans, node.Name)
+		}
+	}
+
+	if len(orphans) > 0 {
+		reason := "OrphanNodes"
+		sort.Strings(orphans)
+		err := fmt.Errorf("%d worker node(s) not backed by any machine: %s", len(orphans), strings.Join(orphans, ", "))
+		return false, &reason, err
+	}
+	return true, nil, nil
+}
+
+func getNodeCondition(conditions []corev1.NodeCondition, conditionType corev1.NodeConditionType) *corev1.NodeCondition {
+	for _, condition := range conditions {
+		if condition.Type == conditionType {
+			return &condition
+		}
+	}
+	return nil
+}
+
+func checkMachineDeploymentsHibernated(machineDeployments []machinev1alpha1.MachineDeployment) error {
+	var details []string
+	for _, deployment := range machineDeployments {
+		if deployment.Spec.Replicas != 0 || deployment.Status.Replicas != 0 {
+			details = append(details, fmt.Sprintf("%s (%d desired, %d existing machines)", deployment.Name, deployment.Spec.Replicas, deployment.Status.Replicas))
+		}
+	}
+
+	if len(details) > 0 {
+		sort.Strings(details)
+		return fmt.Errorf("%d machine deployment(s) not scaled down: %s", len(details), strings.Join(details, "; "))
+	}
+	return nil
+}
+
+func checkMachineDeploymentsWokenUp(machineDeployments []machinev1alpha1.MachineDeployment) error {
+	if len(machineDeployments) > 0 && getDesiredMachineCount(machineDeployments) == 0 {
+		return fmt.Errorf("none of the %d machine deployment(s) is scaled up", len(machineDeployments))
+	}
+
+	var details []string
+	for _, deployment := range machineDeployments {
+		if deployment.DeletionTimestamp == nil && deployment.Status.AvailableReplicas < deployment.Spec.Replicas {
+			details = append(details, fmt.Sprintf("%s (%d/%d machines available)", deployment.Name, deployment.Status.AvailableReplicas, deployment.Spec.Replicas))
+		}
+	}
+
+	if len(details) > 0 {
+		sort.Strings(details)
+		return fmt.Errorf("%d machine deployment(s) not woken up: %s", len(details), strings.Join(details, "; "))
+	}
+	return nil
+}
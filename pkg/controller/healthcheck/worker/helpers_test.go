@@ -16,12 +16,15 @@ package worker
 
 import (
 	"testing"
+	"time"
 
+	"github.com/Masterminds/semver"
 	gardenv1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -106,4 +109,167 @@ var _ = Describe("health", func() {
 			}, HaveOccurred()),
 		)
 	})
+
+	Context("checkNodesWithoutPressure", func() {
+		It("should succeed if no node is under pressure", func() {
+			nodes := []corev1.Node{
+				newNode("node-1", corev1.NodeCondition{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse}),
+				newNode("node-2"),
+			}
+
+			isHealthy, _, err := checkNodesWithoutPressure(nodes)
+			Expect(isHealthy).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should report all nodes and their pressure conditions", func() {
+			nodes := []corev1.Node{
+				newNode("node-2", corev1.NodeCondition{Type: corev1.NodePIDPressure, Status: corev1.ConditionTrue}),
+				newNode("node-1",
+					corev1.NodeCondition{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue},
+					corev1.NodeCondition{Type: corev1.NodeDiskPressure, Status: corev1.ConditionTrue},
+				),
+				newNode("node-3"),
+			}
+
+			isHealthy, reason, err := checkNodesWithoutPressure(nodes)
+			Expect(isHealthy).To(BeFalse())
+			Expect(*reason).To(Equal("NodesUnderPressure"))
+			Expect(err).To(MatchError("2 worker node(s) under pressure: node-1 (MemoryPressure, DiskPressure); node-2 (PIDPressure)"))
+		})
+	})
+
+	Context("checkNodesReady", func() {
+		var (
+			now         = time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+			gracePeriod = 5 * time.Minute
+		)
+
+		It("should ignore nodes which are not ready for less than the grace period", func() {
+			nodes := []corev1.Node{
+				newNode("node-1", corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionTrue}),
+				newNode("node-2", corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionFalse, LastTransitionTime: metav1.NewTime(now.Add(-time.Minute))}),
+			}
+
+			isHealthy, _, err := checkNodesReady(nodes, now, gracePeriod)
+			Expect(isHealthy).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should report nodes which are not ready for longer than the grace period", func() {
+			nodes := []corev1.Node{
+				newNode("node-1", corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionUnknown, Reason: "NodeStatusUnknown", LastTransitionTime: metav1.NewTime(now.Add(-10 * time.Minute))}),
+				newNode("node-2"),
+			}
+			nodes[1].CreationTimestamp = metav1.NewTime(now.Add(-time.Hour))
+
+			isHealthy, reason, err := checkNodesReady(nodes, now, gracePeriod)
+			Expect(isHealthy).To(BeFalse())
+			Expect(*reason).To(Equal("NodesNotReady"))
+			Expect(err).To(MatchError(`2 worker node(s) not ready for longer than 5m0s: node-1 (Ready=Unknown, reason "NodeStatusUnknown" for 10m0s); node-2 (no Ready condition for 1h0m0s)`))
+		})
+	})
+
+	Context("checkKubeletVersionSkew", func() {
+		var controlPlaneVersion = semver.MustParse("1.17.3")
+
+		DescribeTable("kubelet versions",
+			func(kubeletVersion string, matcher types.GomegaMatcher) {
+				node := newNode("node-1")
+				node.Status.NodeInfo.KubeletVersion = kubeletVersion
+
+				isHealthy, _, err := checkKubeletVersionSkew([]corev1.Node{node}, controlPlaneVersion, 2)
+				Expect(err).To(matcher)
+				Expect(isHealthy).To(Equal(err == nil))
+			},
+			Entry("same version", "v1.17.3", BeNil()),
+			Entry("older patch version", "v1.17.0", BeNil()),
+			Entry("maximum allowed skew", "v1.15.10", BeNil()),
+			Entry("newer patch version", "v1.17.4", BeNil()),
+			Entry("newer minor version", "v1.18.0", MatchError(ContainSubstring("node-1 (kubelet v1.18.0 is newer than control plane)"))),
+			Entry("too old minor version", "v1.14.0", MatchError(ContainSubstring("node-1 (kubelet v1.14.0 is more than 2 minor versions older than control plane)"))),
+			Entry("unparseable version", "foo", MatchError(ContainSubstring(`node-1 (unparseable kubelet version "foo")`))),
+		)
+	})
+
+	Context("checkNoOrphanNodes", func() {
+		var (
+			now         = time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+			gracePeriod = 5 * time.Minute
+		)
+
+		newOldNode := func(name string) corev1.Node {
+			node := newNode(name)
+			node.CreationTimestamp = metav1.NewTime(now.Add(-time.Hour))
+			return node
+		}
+
+		It("should report nodes which are not backed by a machine", func() {
+			nodes := []corev1.Node{newOldNode("node-1"), newOldNode("node-2"), newOldNode("node-3")}
+			machines := []gardenv1alpha1.Machine{
+				{Status: gardenv1alpha1.MachineStatus{Node: "node-2"}},
+				{},
+			}
+
+			isHealthy, reason, err := checkNoOrphanNodes(nodes, machines, now, gracePeriod)
+			Expect(isHealthy).To(BeFalse())
+			Expect(*reason).To(Equal("OrphanNodes"))
+			Expect(err).To(MatchError("2 worker node(s) not backed by any machine: node-1, node-3"))
+		})
+
+		It("should ignore nodes which are younger than the grace period", func() {
+			nodes := []corev1.Node{newNode("node-1")}
+			nodes[0].CreationTimestamp = metav1.NewTime(now.Add(-time.Minute))
+
+			isHealthy, _, err := checkNoOrphanNodes(nodes, nil, now, gracePeriod)
+			Expect(isHealthy).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should succeed if all nodes are backed by machines", func() {
+			nodes := []corev1.Node{newOldNode("node-1")}
+			machines := []gardenv1alpha1.Machine{{Status: gardenv1alpha1.MachineStatus{Node: "node-1"}}}
+
+			isHealthy, _, err := checkNoOrphanNodes(nodes, machines, now, gracePeriod)
+			Expect(isHealthy).To(BeTrue())
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("checkMachineDeploymentsHibernated", func() {
+		It("should report machine deployments which are not scaled down", func() {
+			machineDeployments := []gardenv1alpha1.MachineDeployment{
+				{ObjectMeta: metav1.ObjectMeta{Name: "pool-1"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "pool-2"}, Status: gardenv1alpha1.MachineDeploymentStatus{Replicas: 2}},
+			}
+
+			Expect(checkMachineDeploymentsHibernated(machineDeployments)).To(MatchError("1 machine deployment(s) not scaled down: pool-2 (0 desired, 2 existing machines)"))
+			Expect(checkMachineDeploymentsHibernated(machineDeployments[:1])).To(Succeed())
+		})
+	})
+
+	Context("checkMachineDeploymentsWokenUp", func() {
+		It("should fail if no machine deployment is scaled up", func() {
+			machineDeployments := []gardenv1alpha1.MachineDeployment{{ObjectMeta: metav1.ObjectMeta{Name: "pool-1"}}}
+
+			Expect(checkMachineDeploymentsWokenUp(machineDeployments)).To(MatchError("none of the 1 machine deployment(s) is scaled up"))
+		})
+
+		It("should report machine deployments without enough available machines", func() {
+			machineDeployments := []gardenv1alpha1.MachineDeployment{
+				{ObjectMeta: metav1.ObjectMeta{Name: "pool-1"}, Spec: gardenv1alpha1.MachineDeploymentSpec{Replicas: 2}, Status: gardenv1alpha1.MachineDeploymentStatus{AvailableReplicas: 2}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "pool-2"}, Spec: gardenv1alpha1.MachineDeploymentSpec{Replicas: 3}, Status: gardenv1alpha1.MachineDeploymentStatus{AvailableReplicas: 1}},
+			}
+
+			Expect(checkMachineDeploymentsWokenUp(machineDeployments)).To(MatchError("1 machine deployment(s) not woken up: pool-2 (1/3 machines available)"))
+			Expect(checkMachineDeploymentsWokenUp(machineDeployments[:1])).To(Succeed())
+		})
+	})
 })
+
+func newNode(name string, conditions ...corev1.NodeCondition) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     corev1.NodeStatus{Conditions: conditions},
+	}
+}
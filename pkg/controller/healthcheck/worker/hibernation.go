@@ -0,0 +1,101 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/healthcheck"
+
+	machinev1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// MachineDeploymentsHibernationHealthChecker contains all the information for the machine deployments hibernation HealthCheck
+// This check assumes that the MachineControllerManager (https://github.com/gardener/machine-controller-manager) has been deployed by the Worker extension controller
+type MachineDeploymentsHibernationHealthChecker struct {
+	logger      logr.Logger
+	seedClient  client.Client
+	shootClient client.Client
+	transition  healthcheck.HibernationTransition
+	timeout     time.Duration
+	now         func() time.Time
+}
+
+// NewMachineDeploymentsHibernationChecker is a hibernation aware health check function which checks that all machine
+// deployments are scaled down and all machines are drained while the cluster is hibernating or hibernated, and that the
+// machine deployments are scaled up again and all their machines are available while the cluster is waking up.
+// Transitions which do not complete within the given timeout are reported as unhealthy.
+func NewMachineDeploymentsHibernationChecker(timeout time.Duration) healthcheck.HealthCheck {
+	return &MachineDeploymentsHibernationHealthChecker{
+		timeout: timeout,
+		now:     time.Now,
+	}
+}
+
+// InjectSeedClient injects the seed client
+func (healthChecker *MachineDeploymentsHibernationHealthChecker) InjectSeedClient(seedClient client.Client) {
+	healthChecker.seedClient = seedClient
+}
+
+// InjectShootClient injects the shoot client
+func (healthChecker *MachineDeploymentsHibernationHealthChecker) InjectShootClient(shootClient client.Client) {
+	healthChecker.shootClient = shootClient
+}
+
+// InjectHibernationTransition injects the current hibernation state of the cluster
+func (healthChecker *MachineDeploymentsHibernationHealthChecker) InjectHibernationTransition(transition healthcheck.HibernationTransition) {
+	healthChecker.transition = transition
+}
+
+// SetLoggerSuffix injects the logger
+func (healthChecker *MachineDeploymentsHibernationHealthChecker) SetLoggerSuffix(provider, extension string) {
+	healthChecker.logger = log.Log.WithName(fmt.Sprintf("%s-%s-healthcheck-machine-deployments-hibernation", provider, extension))
+}
+
+// DeepCopy clones the healthCheck struct by making a copy and returning the pointer to that new copy
+func (healthChecker *MachineDeploymentsHibernationHealthChecker) DeepCopy() healthcheck.HealthCheck {
+	copy := *healthChecker
+	return &copy
+}
+
+// Check executes the health check
+func (healthChecker *MachineDeploymentsHibernationHealthChecker) Check(ctx context.Context, request types.NamespacedName) (*healthcheck.SingleCheckResult, error) {
+	machineDeploymentList := &machinev1alpha1.MachineDeploymentList{}
+	if err := healthChecker.seedClient.List(ctx, machineDeploymentList, client.InNamespace(request.Namespace)); err != nil {
+		err := fmt.Errorf("failed to list machine deployments in namespace %s: %v", request.Namespace, err)
+		healthChecker.logger.Error(err, "Health check failed")
+		return nil, err
+	}
+
+	var err error
+	if healthChecker.transition.State == healthcheck.HibernationStateWakingUp {
+		err = checkMachineDeploymentsWokenUp(machineDeploymentList.Items)
+	} else {
+		err = checkMachineDeploymentsHibernated(machineDeploymentList.Items)
+	}
+
+	result := healthcheck.HibernationTransitionResult(healthChecker.transition, healthChecker.timeout, healthChecker.now(), err)
+	if !result.IsHealthy {
+		healthChecker.logger.Error(errors.New(result.Detail), "Health check failed")
+	}
+	return result, nil
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	"github.com/gardener/gardener-extensions/pkg/controller/healthcheck"
+
+	"github.com/Masterminds/semver"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DefaultMaxKubeletMinorVersionSkew is the default number of minor versions a kubelet may be older than the
+// control plane, see https://kubernetes.io/docs/setup/release/version-skew-policy/#kubelet.
+const DefaultMaxKubeletMinorVersionSkew = 2
+
+// KubeletVersionSkewHealthChecker contains all the information for the kubelet version skew HealthCheck
+type KubeletVersionSkewHealthChecker struct {
+	logger       logr.Logger
+	seedClient   client.Client
+	shootClient  client.Client
+	maxMinorSkew int
+}
+
+// NewKubeletVersionSkewChecker is a health check function which checks that the kubelet versions of all nodes of
+// the shoot cluster are not newer than the control plane version and at most the given number of minor versions older.
+// The control plane version is read from the shoot in the Cluster resource.
+func NewKubeletVersionSkewChecker(maxMinorSkew int) healthcheck.HealthCheck {
+	return &KubeletVersionSkewHealthChecker{
+		maxMinorSkew: maxMinorSkew,
+	}
+}
+
+// InjectSeedClient injects the seed client
+func (healthChecker *KubeletVersionSkewHealthChecker) InjectSeedClient(seedClient client.Client) {
+	healthChecker.seedClient = seedClient
+}
+
+// InjectShootClient injects the shoot client
+func (healthChecker *KubeletVersionSkewHealthChecker) InjectShootClient(shootClient client.Client) {
+	healthChecker.shootClient = shootClient
+}
+
+// SetLoggerSuffix injects the logger
+func (healthChecker *KubeletVersionSkewHealthChecker) SetLoggerSuffix(provider, extension string) {
+	healthChecker.logger = log.Log.WithName(fmt.Sprintf("%s-%s-healthcheck-kubelet-version-skew", provider, extension))
+}
+
+// DeepCopy clones the healthCheck struct by making a copy and returning the pointer to that new copy
+func (healthChecker *KubeletVersionSkewHealthChecker) DeepCopy() healthcheck.HealthCheck {
+	copy := *healthChecker
+	return &copy
+}
+
+// Check executes the health check
+func (healthChecker *KubeletVersionSkewHealthChecker) Check(ctx context.Context, request types.NamespacedName) (*healthcheck.SingleCheckResult, error) {
+	cluster, err := extensionscontroller.GetCluster(ctx, healthChecker.seedClient, request.Namespace)
+	if err != nil {
+		err := fmt.Errorf("failed to read cluster resource for namespace %s: %v", request.Namespace, err)
+		healthChecker.logger.Error(err, "Health check failed")
+		return nil, err
+	}
+	if cluster.Shoot == nil {
+		err := fmt.Errorf("cluster resource for namespace %s does not contain a shoot", request.Namespace)
+		healthChecker.logger.Error(err, "Health check failed")
+		return nil, err
+	}
+
+	controlPlaneVersion, err := semver.NewVersion(cluster.Shoot.Spec.Kubernetes.Version)
+	if err != nil {
+		err := fmt.Errorf("failed to parse control plane version %q: %v", cluster.Shoot.Spec.Kubernetes.Version, err)
+		healthChecker.logger.Error(err, "Health check failed")
+		return nil, err
+	}
+
+	nodeList := &corev1.NodeList{}
+	if err := healthChecker.shootClient.List(ctx, nodeList); err != nil {
+		err := fmt.Errorf("failed to list shoot nodes: %v", err)
+		healthChecker.logger.Error(err, "Health check failed")
+		return nil, err
+	}
+
+	if isHealthy, reason, err := checkKubeletVersionSkew(nodeList.Items, controlPlaneVersion, int64(healthChecker.maxMinorSkew)); !isHealthy {
+		healthChecker.logger.Error(err, "Health check failed")
+		return &healthcheck.SingleCheckResult{
+			IsHealthy: false,
+			Detail:    err.Error(),
+			Reason:    *reason,
+		}, nil
+	}
+	return &healthcheck.SingleCheckResult{
+		IsHealthy: true,
+	}, nil
+}
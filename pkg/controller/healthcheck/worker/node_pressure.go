@@ -0,0 +1,84 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/healthcheck"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// NodePressureHealthChecker contains all the information for the node pressure HealthCheck
+type NodePressureHealthChecker struct {
+	logger      logr.Logger
+	seedClient  client.Client
+	shootClient client.Client
+}
+
+// NewNodePressureChecker is a health check function which checks that no node of the shoot cluster reports
+// memory, disk or PID pressure.
+func NewNodePressureChecker() healthcheck.HealthCheck {
+	return &NodePressureHealthChecker{}
+}
+
+// InjectSeedClient injects the seed client
+func (healthChecker *NodePressureHealthChecker) InjectSeedClient(seedClient client.Client) {
+	healthChecker.seedClient = seedClient
+}
+
+// InjectShootClient injects the shoot client
+func (healthChecker *NodePressureHealthChecker) InjectShootClient(shootClient client.Client) {
+	healthChecker.shootClient = shootClient
+}
+
+// SetLoggerSuffix injects the logger
+func (healthChecker *NodePressureHealthChecker) SetLoggerSuffix(provider, extension string) {
+	healthChecker.logger = log.Log.WithName(fmt.Sprintf("%s-%s-healthcheck-node-pressure", provider, extension))
+}
+
+// DeepCopy clones the healthCheck struct by making a copy and returning the pointer to that new copy
+func (healthChecker *NodePressureHealthChecker) DeepCopy() healthcheck.HealthCheck {
+	copy := *healthChecker
+	return &copy
+}
+
+// Check executes the health check
+func (healthChecker *NodePressureHealthChecker) Check(ctx context.Context, _ types.NamespacedName) (*healthcheck.SingleCheckResult, error) {
+	nodeList := &corev1.NodeList{}
+	if err := healthChecker.shootClient.List(ctx, nodeList); err != nil {
+		err := fmt.Errorf("failed to list shoot nodes: %v", err)
+		healthChecker.logger.Error(err, "Health check failed")
+		return nil, err
+	}
+
+	if isHealthy, reason, err := checkNodesWithoutPressure(nodeList.Items); !isHealthy {
+		healthChecker.logger.Error(err, "Health check failed")
+		return &healthcheck.SingleCheckResult{
+			IsHealthy: false,
+			Detail:    err.Error(),
+			Reason:    *reason,
+		}, nil
+	}
+	return &healthcheck.SingleCheckResult{
+		IsHealthy: true,
+	}, nil
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/healthcheck"
+
+	machinev1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DefaultOrphanNodeGracePeriod is the default period a node may exist without being backed by a machine before it is
+// considered an orphan.
+const DefaultOrphanNodeGracePeriod = 5 * time.Minute
+
+// OrphanNodesHealthChecker contains all the information for the orphan nodes HealthCheck
+// This check assumes that the MachineControllerManager (https://github.com/gardener/machine-controller-manager) has been deployed by the Worker extension controller
+type OrphanNodesHealthChecker struct {
+	logger      logr.Logger
+	seedClient  client.Client
+	shootClient client.Client
+	gracePeriod time.Duration
+	now         func() time.Time
+}
+
+// NewOrphanNodesChecker is a health check function which checks that every node of the shoot cluster is backed by a
+// Machine in the seed cluster. Nodes younger than the given grace period are not considered orphans, since the
+// machine-controller-manager records the node of a machine only some time after the node joined the cluster.
+func NewOrphanNodesChecker(gracePeriod time.Duration) healthcheck.HealthCheck {
+	return &OrphanNodesHealthChecker{
+		gracePeriod: gracePeriod,
+		now:         time.Now,
+	}
+}
+
+// InjectSeedClient injects the seed client
+func (healthChecker *OrphanNodesHealthChecker) InjectSeedClient(seedClient client.Client) {
+	healthChecker.seedClient = seedClient
+}
+
+// InjectShootClient injects the shoot client
+func (healthChecker *OrphanNodesHealthChecker) InjectShootClient(shootClient client.Client) {
+	healthChecker.shootClient = shootClient
+}
+
+// SetLoggerSuffix injects the logger
+func (healthChecker *OrphanNodesHealthChecker) SetLoggerSuffix(provider, extension string) {
+	healthChecker.logger = log.Log.WithName(fmt.Sprintf("%s-%s-healthcheck-orphan-nodes", provider, extension))
+}
+
+// DeepCopy clones the healthCheck struct by making a copy and returning the pointer to that new copy
+func (healthChecker *OrphanNodesHealthChecker) DeepCopy() healthcheck.HealthCheck {
+	copy := *healthChecker
+	return &copy
+}
+
+// Check executes the health check
+func (healthChecker *OrphanNodesHealthChecker) Check(ctx context.Context, request types.NamespacedName) (*healthcheck.SingleCheckResult, error) {
+	machineList := &machinev1alpha1.MachineList{}
+	if err := healthChecker.seedClient.List(ctx, machineList, client.InNamespace(request.Namespace)); err != nil {
+		err := fmt.Errorf("failed to list machines in namespace %s: %v", request.Namespace, err)
+		healthChecker.logger.Error(err, "Health check failed")
+		return nil, err
+	}
+
+	nodeList := &corev1.NodeList{}
+	if err := healthChecker.shootClient.List(ctx, nodeList); err != nil {
+		err := fmt.Errorf("failed to list shoot nodes: %v", err)
+		healthChecker.logger.Error(err, "Health check failed")
+		return nil, err
+	}
+
+	if isHealthy, reason, err := checkNoOrphanNodes(nodeList.Items, machineList.Items, healthChecker.now(), healthChecker.gracePeriod); !isHealthy {
+		healthChecker.logger.Error(err, "Health check failed")
+		return &healthcheck.SingleCheckResult{
+			IsHealthy: false,
+			Detail:    err.Error(),
+			Reason:    *reason,
+		}, nil
+	}
+	return &healthcheck.SingleCheckResult{
+		IsHealthy: true,
+	}, nil
+}
@@ -15,9 +15,11 @@
 package operatingsystemconfig
 
 import (
+	extensionshandler "github.com/gardener/gardener-extensions/pkg/handler"
 	extensionspredicate "github.com/gardener/gardener-extensions/pkg/predicate"
 
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -36,6 +38,8 @@ const (
 )
 
 // AddArgs are arguments for adding an operatingsystemconfig controller to a manager.
+// Note that the controller watches all secrets to regenerate the cloud configs whose files reference them, hence the
+// cache of the manager holds all secrets of the cluster, unless they are already cached for other controllers anyway.
 type AddArgs struct {
 	// Actuator is an operatingsystemconfig actuator.
 	Actuator Actuator
@@ -55,7 +59,8 @@ type AddArgs struct {
 func Add(mgr manager.Manager, args AddArgs) error {
 	args.ControllerOptions.Reconciler = NewReconciler(args.Actuator)
 	predicates := extensionspredicate.AddTypePredicate(args.Predicates, args.Types...)
-	return add(mgr, args.ControllerOptions, predicates)
+	typePredicates := extensionspredicate.AddTypePredicate(nil, args.Types...)
+	return add(mgr, args.ControllerOptions, predicates, typePredicates)
 }
 
 // DefaultPredicates returns the default predicates for an operatingsystemconfig reconciler.
@@ -79,7 +84,10 @@ func DefaultPredicates(ignoreOperationAnnotation bool) []predicate.Predicate {
 	}
 }
 
-func add(mgr manager.Manager, options controller.Options, predicates []predicate.Predicate) error {
+// add adds the controller to the given manager. The given predicates filter the OperatingSystemConfig events, the
+// given type predicates filter the OperatingSystemConfigs that are enqueued for changed secrets. The latter must not
+// contain the lifecycle predicates, as they would drop configs that have already been reconciled successfully.
+func add(mgr manager.Manager, options controller.Options, predicates, typePredicates []predicate.Predicate) error {
 	ctrl, err := controller.New(ControllerName, mgr, options)
 	if err != nil {
 		return err
@@ -89,5 +97,12 @@ func add(mgr manager.Manager, options controller.Options, predicates []predicate
 		return err
 	}
 
-	return nil
+	// Files may reference secrets, hence the cloud config has to be regenerated as soon as one of them changes.
+	if err := addSecretRefIndex(mgr.GetFieldIndexer()); err != nil {
+		return err
+	}
+
+	return ctrl.Watch(&source.Kind{Type: &corev1.Secret{}}, &extensionshandler.EnqueueRequestsFromMapFunc{
+		ToRequests: extensionshandler.SimpleMapper(SecretToOperatingSystemConfigMapper(typePredicates), extensionshandler.UpdateWithNew),
+	})
 }
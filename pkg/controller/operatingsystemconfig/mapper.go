@@ -0,0 +1,81 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operatingsystemconfig
+
+import (
+	"sync"
+
+	extensionshandler "github.com/gardener/gardener-extensions/pkg/handler"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// SecretRefIndexField is the name of the field index that maps OperatingSystemConfigs to the names of the secrets
+// referenced by their files.
+const SecretRefIndexField = "spec.files.content.secretRef.name"
+
+var (
+	indexedFieldIndexersLock sync.Mutex
+	indexedFieldIndexers     = map[client.FieldIndexer]struct{}{}
+)
+
+// addSecretRefIndex adds the SecretRefIndexField index for OperatingSystemConfigs to the given field indexer, unless
+// it was already added before.
+func addSecretRefIndex(indexer client.FieldIndexer) error {
+	indexedFieldIndexersLock.Lock()
+	defer indexedFieldIndexersLock.Unlock()
+
+	if _, ok := indexedFieldIndexers[indexer]; ok {
+		return nil
+	}
+
+	if err := indexer.IndexField(&extensionsv1alpha1.OperatingSystemConfig{}, SecretRefIndexField, SecretRefIndexerFunc); err != nil {
+		return err
+	}
+
+	indexedFieldIndexers[indexer] = struct{}{}
+	return nil
+}
+
+// SecretRefIndexerFunc returns the names of the secrets referenced by the files of the given OperatingSystemConfig.
+func SecretRefIndexerFunc(obj runtime.Object) []string {
+	config, ok := obj.(*extensionsv1alpha1.OperatingSystemConfig)
+	if !ok {
+		return nil
+	}
+
+	var (
+		names []string
+		seen  = make(map[string]bool)
+	)
+	for _, file := range config.Spec.Files {
+		if ref := file.Content.SecretRef; ref != nil && !seen[ref.Name] {
+			names = append(names, ref.Name)
+			seen[ref.Name] = true
+		}
+	}
+	return names
+}
+
+// SecretToOperatingSystemConfigMapper returns a mapper that returns requests for OperatingSystemConfigs whose
+// referenced secrets have been modified. The given predicates should only select the OperatingSystemConfigs of the
+// handled types, since predicates like the DefaultPredicates drop configs that have been reconciled successfully.
+func SecretToOperatingSystemConfigMapper(predicates []predicate.Predicate) handler.Mapper {
+	return extensionshandler.SecretToObjectMapper(func() runtime.Object { return &extensionsv1alpha1.OperatingSystemConfigList{} }, SecretRefIndexField, predicates)
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operatingsystemconfig_test
+
+import (
+	"context"
+
+	. "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig"
+	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
+	extensionspredicate "github.com/gardener/gardener-extensions/pkg/predicate"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+)
+
+var _ = Describe("Mapper", func() {
+	Describe("#SecretRefIndexerFunc", func() {
+		It("should return the names of all referenced secrets", func() {
+			osc := &extensionsv1alpha1.OperatingSystemConfig{
+				Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+					Files: []extensionsv1alpha1.File{
+						{Path: "/etc/foo", Content: extensionsv1alpha1.FileContent{SecretRef: &extensionsv1alpha1.FileContentSecretRef{Name: "foo", DataKey: "foo"}}},
+						{Path: "/etc/bar", Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: "bar"}}},
+						{Path: "/etc/baz", Content: extensionsv1alpha1.FileContent{SecretRef: &extensionsv1alpha1.FileContentSecretRef{Name: "baz", DataKey: "baz"}}},
+						{Path: "/etc/qux", Content: extensionsv1alpha1.FileContent{SecretRef: &extensionsv1alpha1.FileContentSecretRef{Name: "foo", DataKey: "qux"}}},
+					},
+				},
+			}
+
+			Expect(SecretRefIndexerFunc(osc)).To(Equal([]string{"foo", "baz"}))
+		})
+
+		It("should return nothing for other objects", func() {
+			Expect(SecretRefIndexerFunc(&corev1.Secret{})).To(BeEmpty())
+		})
+	})
+
+	Describe("#SecretToOperatingSystemConfigMapper", func() {
+		var (
+			ctrl *gomock.Controller
+			c    *mockclient.MockClient
+
+			secret = &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "shoot--foo--bar", Name: "secret"}}
+			osc    *extensionsv1alpha1.OperatingSystemConfig
+		)
+
+		BeforeEach(func() {
+			ctrl = gomock.NewController(GinkgoT())
+			c = mockclient.NewMockClient(ctrl)
+
+			// a config that has been reconciled successfully, i.e. that is filtered by the DefaultPredicates
+			osc = &extensionsv1alpha1.OperatingSystemConfig{
+				ObjectMeta: metav1.ObjectMeta{Namespace: secret.Namespace, Name: "osc", Generation: 1},
+				Spec:       extensionsv1alpha1.OperatingSystemConfigSpec{DefaultSpec: extensionsv1alpha1.DefaultSpec{Type: "foo"}},
+				Status: extensionsv1alpha1.OperatingSystemConfigStatus{
+					DefaultStatus: extensionsv1alpha1.DefaultStatus{
+						ObservedGeneration: 1,
+						LastOperation:      &gardencorev1beta1.LastOperation{State: gardencorev1beta1.LastOperationStateSucceeded},
+					},
+				},
+			}
+
+			c.EXPECT().
+				List(gomock.Any(), gomock.AssignableToTypeOf(&extensionsv1alpha1.OperatingSystemConfigList{}), client.InNamespace(secret.Namespace), client.MatchingFields{SecretRefIndexField: secret.Name}).
+				DoAndReturn(func(_ context.Context, list *extensionsv1alpha1.OperatingSystemConfigList, _ ...client.ListOption) error {
+					list.Items = []extensionsv1alpha1.OperatingSystemConfig{*osc}
+					return nil
+				})
+		})
+
+		AfterEach(func() {
+			ctrl.Finish()
+		})
+
+		mapSecret := func(mapper handler.Mapper) []reconcile.Request {
+			ok, err := inject.ClientInto(c, mapper)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			return mapper.Map(handler.MapObject{Meta: secret, Object: secret})
+		}
+
+		It("should not enqueue successfully reconciled configs if the lifecycle predicates are used", func() {
+			predicates := extensionspredicate.AddTypePredicate(DefaultPredicates(false), "foo")
+			Expect(mapSecret(SecretToOperatingSystemConfigMapper(predicates))).To(BeEmpty())
+		})
+
+		It("should enqueue successfully reconciled configs of the handled types", func() {
+			predicates := extensionspredicate.AddTypePredicate(nil, "foo")
+			Expect(mapSecret(SecretToOperatingSystemConfigMapper(predicates))).To(ConsistOf(reconcile.Request{NamespacedName: client.ObjectKey{Namespace: osc.Namespace, Name: osc.Name}}))
+		})
+
+		It("should not enqueue configs of other types", func() {
+			predicates := extensionspredicate.AddTypePredicate(nil, "bar")
+			Expect(mapSecret(SecretToOperatingSystemConfigMapper(predicates))).To(BeEmpty())
+		})
+	})
+})
@@ -20,25 +20,47 @@ import (
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 // Actuator uses a generator to render an OperatingSystemConfiguration for an Operating System
 type Actuator struct {
-	scheme    *runtime.Scheme
-	client    client.Client
-	logger    logr.Logger
-	osName    string
-	generator generator.Generator
+	scheme        *runtime.Scheme
+	client        client.Client
+	logger        logr.Logger
+	osName        string
+	generator     generator.Generator
+	userDataLimit int
+	recorder      record.EventRecorder
+}
+
+// Options are options for the Actuator.
+type Options struct {
+	// UserDataLimit is the maximum size of the generated cloud config in bytes, e.g. the user data limit of the cloud
+	// provider. It only applies to configs with purpose 'provision', which are passed as user data to the machines.
+	// Cloud configs exceeding the limit are compressed into a self-extracting script if they are scripts, and rejected
+	// otherwise. A limit <= 0 means that the size is not limited.
+	UserDataLimit int
+	// Recorder is used to report the changes of the OperatingSystemConfigs as events. If nil, the changes are only
+	// logged.
+	Recorder record.EventRecorder
 }
 
 // NewActuator creates a new actuator with the given logger.
 func NewActuator(osName string, generator generator.Generator) operatingsystemconfig.Actuator {
+	return NewActuatorWithOptions(osName, generator, Options{})
+}
+
+// NewActuatorWithOptions creates a new actuator with the given options.
+func NewActuatorWithOptions(osName string, generator generator.Generator, opts Options) operatingsystemconfig.Actuator {
 	return &Actuator{
-		logger:    log.Log.WithName(osName + "-operatingsystemconfig-actuator"),
-		osName:    osName,
-		generator: generator,
+		logger:        log.Log.WithName(osName + "-operatingsystemconfig-actuator"),
+		osName:        osName,
+		generator:     generator,
+		userDataLimit: opts.UserDataLimit,
+		recorder:      opts.Recorder,
 	}
 }
 
@@ -24,10 +24,35 @@ import (
 // Reconcile reconciles the update of a OperatingSystemConfig regenerating the os-specific format
 func (a *Actuator) Reconcile(ctx context.Context, config *extensionsv1alpha1.OperatingSystemConfig) ([]byte, *string, []string, error) {
 
-	cloudConfig, cmd, err := CloudConfigFromOperatingSystemConfig(ctx, a.client, config, a.generator)
+	data, err := GeneratorConfigFromOperatingSystemConfig(ctx, a.client, config)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("could not generate cloud config: %v", err)
 	}
 
-	return []byte(cloudConfig), cmd, OperatingSystemConfigUnitNames(config), nil
+	if err := ValidateGeneratorConfig(data); err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid operating system config: %v", err)
+	}
+
+	cloudConfig, cmd, err := a.generator.Generate(data)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("could not generate cloud config: %v", err)
+	}
+
+	if err := ValidateScript(ctx, a.logger, cloudConfig); err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid cloud config: %v", err)
+	}
+
+	// Only the cloud config for provisioning is passed as user data to the machines
+	if config.Spec.Purpose == extensionsv1alpha1.OperatingSystemConfigPurposeProvision {
+		cloudConfig, err = fitUserData(cloudConfig, a.userDataLimit)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("could not generate cloud config for %s/%s: %v", config.Namespace, config.Name, err)
+		}
+	}
+
+	if err := a.reportChanges(ctx, config, data); err != nil {
+		return nil, nil, nil, fmt.Errorf("could not report changes of operating system config: %v", err)
+	}
+
+	return cloudConfig, cmd, OperatingSystemConfigUnitNames(config), nil
 }
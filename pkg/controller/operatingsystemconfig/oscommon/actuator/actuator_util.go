@@ -30,6 +30,8 @@ package actuator
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
 	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/cloudinit"
 	commonosgenerator "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
@@ -39,17 +41,79 @@ import (
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// AnnotationFileAttributes is the annotation of an OperatingSystemConfig containing the FileAttributes encoded as JSON.
+const AnnotationFileAttributes = "operatingsystemconfig.extensions.gardener.cloud/file-attributes"
+
+// FileAttributes are the attributes of the files of an OperatingSystemConfig which are not part of its spec, as well as
+// additional directories and symlinks to be created.
+type FileAttributes struct {
+	// Files are the attributes of the files, keyed by their path.
+	Files map[string]FileAttribute `json:"files,omitempty"`
+	// Directories are the directories to be created.
+	Directories []*commonosgenerator.Directory `json:"directories,omitempty"`
+	// Symlinks are the symbolic links to be created.
+	Symlinks []*commonosgenerator.Symlink `json:"symlinks,omitempty"`
+}
+
+// FileAttribute are the attributes of a file which are not part of the OperatingSystemConfig spec.
+type FileAttribute struct {
+	// Owner is the name of the user owning the file. Defaults to root.
+	Owner string `json:"owner,omitempty"`
+	// Group is the name of the group owning the file. Defaults to root.
+	Group string `json:"group,omitempty"`
+	// Append specifies whether the content is appended to an existing file instead of overwriting it.
+	Append bool `json:"append,omitempty"`
+}
+
+// FileAttributesFromOperatingSystemConfig returns the FileAttributes of the given OperatingSystemConfig. If the
+// AnnotationFileAttributes annotation is not set, empty FileAttributes are returned.
+func FileAttributesFromOperatingSystemConfig(config *extensionsv1alpha1.OperatingSystemConfig) (*FileAttributes, error) {
+	attributes := &FileAttributes{}
+
+	data, ok := config.Annotations[AnnotationFileAttributes]
+	if !ok {
+		return attributes, nil
+	}
+	if err := json.Unmarshal([]byte(data), attributes); err != nil {
+		return nil, fmt.Errorf("could not decode annotation %s: %v", AnnotationFileAttributes, err)
+	}
+	return attributes, nil
+}
+
 // CloudConfigFromOperatingSystemConfig generates a CloudConfig from an OperatingSystemConfig
 // using a Generator
 func CloudConfigFromOperatingSystemConfig(ctx context.Context, cli runtimeclient.Client, config *extensionsv1alpha1.OperatingSystemConfig, generator commonosgenerator.Generator) ([]byte, *string, error) {
+	data, err := GeneratorConfigFromOperatingSystemConfig(ctx, cli, config)
+	if err != nil {
+		return nil, nil, err
+	}
+	return generator.Generate(data)
+}
+
+// GeneratorConfigFromOperatingSystemConfig creates the input of a Generator from an OperatingSystemConfig, retrieving
+// the file contents from secrets if necessary.
+func GeneratorConfigFromOperatingSystemConfig(ctx context.Context, cli runtimeclient.Client, config *extensionsv1alpha1.OperatingSystemConfig) (*commonosgenerator.OperatingSystemConfig, error) {
+	attributes, err := FileAttributesFromOperatingSystemConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
 	files := make([]*commonosgenerator.File, 0, len(config.Spec.Files))
 	for _, file := range config.Spec.Files {
 		data, err := DataForFileContent(ctx, cli, config.Namespace, &file.Content)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 
-		files = append(files, &commonosgenerator.File{Path: file.Path, Content: data, Permissions: file.Permissions})
+		attribute := attributes.Files[file.Path]
+		files = append(files, &commonosgenerator.File{
+			Path:        file.Path,
+			Content:     data,
+			Permissions: file.Permissions,
+			Owner:       attribute.Owner,
+			Group:       attribute.Group,
+			Append:      attribute.Append,
+		})
 	}
 
 	units := make([]*commonosgenerator.Unit, 0, len(config.Spec.Units))
@@ -66,13 +130,15 @@ func CloudConfigFromOperatingSystemConfig(ctx context.Context, cli runtimeclient
 		units = append(units, &commonosgenerator.Unit{Name: unit.Name, Content: content, DropIns: dropIns})
 	}
 
-	return generator.Generate(&commonosgenerator.OperatingSystemConfig{
-		Bootstrap: config.Spec.Purpose == extensionsv1alpha1.OperatingSystemConfigPurposeProvision,
-		CRI:       config.Spec.CRIConfig,
-		Files:     files,
-		Units:     units,
-		Path:      config.Spec.ReloadConfigFilePath,
-	})
+	return &commonosgenerator.OperatingSystemConfig{
+		Bootstrap:   config.Spec.Purpose == extensionsv1alpha1.OperatingSystemConfigPurposeProvision,
+		CRI:         config.Spec.CRIConfig,
+		Files:       files,
+		Directories: attributes.Directories,
+		Symlinks:    attributes.Symlinks,
+		Units:       units,
+		Path:        config.Spec.ReloadConfigFilePath,
+	}, nil
 }
 
 // DataForFileContent returns the content for a FileContent, retrieving from a Secret if necessary.
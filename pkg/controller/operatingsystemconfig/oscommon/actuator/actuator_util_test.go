@@ -0,0 +1,82 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actuator_test
+
+import (
+	"context"
+
+	. "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/actuator"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type recordingGenerator struct {
+	config *generator.OperatingSystemConfig
+}
+
+func (g *recordingGenerator) Generate(config *generator.OperatingSystemConfig) ([]byte, *string, error) {
+	g.config = config
+	return nil, nil, nil
+}
+
+var _ = Describe("Actuator util", func() {
+	var (
+		permissions = int32(0750)
+		osc         *extensionsv1alpha1.OperatingSystemConfig
+	)
+
+	BeforeEach(func() {
+		osc = &extensionsv1alpha1.OperatingSystemConfig{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shoot--foo--bar", Name: "foo"},
+			Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+				Files: []extensionsv1alpha1.File{
+					{Path: "/etc/foo", Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: "foo"}}},
+					{Path: "/etc/bar", Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: "bar"}}},
+				},
+			},
+		}
+	})
+
+	Describe("#CloudConfigFromOperatingSystemConfig", func() {
+		It("should pass the file attributes from the annotation to the generator", func() {
+			osc.Annotations = map[string]string{AnnotationFileAttributes: `{
+  "files": {"/etc/foo": {"owner": "foo", "group": "bar", "append": true}},
+  "directories": [{"path": "/var/lib/foo", "permissions": 488, "owner": "foo"}],
+  "symlinks": [{"path": "/usr/local/bin/foo", "target": "/opt/foo"}]
+}`}
+			g := &recordingGenerator{}
+
+			_, _, err := CloudConfigFromOperatingSystemConfig(context.TODO(), nil, osc, g)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(g.config.Files).To(Equal([]*generator.File{
+				{Path: "/etc/foo", Content: []byte("foo"), Owner: "foo", Group: "bar", Append: true},
+				{Path: "/etc/bar", Content: []byte("bar")},
+			}))
+			Expect(g.config.Directories).To(Equal([]*generator.Directory{{Path: "/var/lib/foo", Permissions: &permissions, Owner: "foo"}}))
+			Expect(g.config.Symlinks).To(Equal([]*generator.Symlink{{Path: "/usr/local/bin/foo", Target: "/opt/foo"}}))
+		})
+
+		It("should fail if the annotation cannot be decoded", func() {
+			osc.Annotations = map[string]string{AnnotationFileAttributes: "{"}
+
+			_, _, err := CloudConfigFromOperatingSystemConfig(context.TODO(), nil, osc, &recordingGenerator{})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,223 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actuator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	commonosgenerator "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// AnnotationContentHashes is the annotation of an OperatingSystemConfig containing the ContentHashes of the last
+	// reconciliation encoded as JSON.
+	AnnotationContentHashes = "operatingsystemconfig.extensions.gardener.cloud/content-hashes"
+
+	// EventReasonContentChanged is the reason of the event reporting the changes of an OperatingSystemConfig.
+	EventReasonContentChanged = "ContentChanged"
+)
+
+// ContentHashes are the hashes of the files, directories, symlinks, units and drop ins of an OperatingSystemConfig.
+type ContentHashes struct {
+	// Files are the hashes of the files, keyed by their path.
+	Files map[string]string `json:"files,omitempty"`
+	// Directories are the hashes of the directories, keyed by their path.
+	Directories map[string]string `json:"directories,omitempty"`
+	// Symlinks are the hashes of the symlinks, keyed by their path.
+	Symlinks map[string]string `json:"symlinks,omitempty"`
+	// Units are the hashes of the units, keyed by their name.
+	Units map[string]string `json:"units,omitempty"`
+	// DropIns are the hashes of the drop ins, keyed by <unit name>.d/<drop in name>.
+	DropIns map[string]string `json:"dropIns,omitempty"`
+}
+
+// ContentDiff is the difference between two ContentHashes.
+type ContentDiff struct {
+	Files       Changes
+	Directories Changes
+	Symlinks    Changes
+	Units       Changes
+	DropIns     Changes
+}
+
+// Changes are the keys which have been added, removed or modified.
+type Changes struct {
+	Added    []string
+	Removed  []string
+	Modified []string
+}
+
+// ContentHashesForConfig computes the ContentHashes of the given generator input.
+func ContentHashesForConfig(config *commonosgenerator.OperatingSystemConfig) *ContentHashes {
+	hashes := &ContentHashes{
+		Files:       make(map[string]string, len(config.Files)),
+		Directories: make(map[string]string, len(config.Directories)),
+		Symlinks:    make(map[string]string, len(config.Symlinks)),
+		Units:       make(map[string]string, len(config.Units)),
+		DropIns:     make(map[string]string),
+	}
+
+	for _, file := range config.Files {
+		permissions := ""
+		if file.Permissions != nil {
+			permissions = fmt.Sprintf("%04o", *file.Permissions)
+		}
+		hashes.Files[file.Path] = hash(file.Content, []byte(permissions), []byte(file.Owner), []byte(file.Group), []byte(fmt.Sprint(file.Append)))
+	}
+	for _, directory := range config.Directories {
+		permissions := ""
+		if directory.Permissions != nil {
+			permissions = fmt.Sprintf("%04o", *directory.Permissions)
+		}
+		hashes.Directories[directory.Path] = hash([]byte(permissions), []byte(directory.Owner), []byte(directory.Group))
+	}
+	for _, symlink := range config.Symlinks {
+		hashes.Symlinks[symlink.Path] = hash([]byte(symlink.Target))
+	}
+	for _, unit := range config.Units {
+		hashes.Units[unit.Name] = hash(unit.Content)
+		for _, dropIn := range unit.DropIns {
+			hashes.DropIns[unit.Name+".d/"+dropIn.Name] = hash(dropIn.Content)
+		}
+	}
+
+	return hashes
+}
+
+// Diff returns the changes from the given old ContentHashes to these ContentHashes.
+func (h *ContentHashes) Diff(old *ContentHashes) *ContentDiff {
+	return &ContentDiff{
+		Files:       diff(old.Files, h.Files),
+		Directories: diff(old.Directories, h.Directories),
+		Symlinks:    diff(old.Symlinks, h.Symlinks),
+		Units:       diff(old.Units, h.Units),
+		DropIns:     diff(old.DropIns, h.DropIns),
+	}
+}
+
+// IsEmpty returns true if nothing changed.
+func (d *ContentDiff) IsEmpty() bool {
+	return d.Files.isEmpty() && d.Directories.isEmpty() && d.Symlinks.isEmpty() && d.Units.isEmpty() && d.DropIns.isEmpty()
+}
+
+// String returns a summary of the changes, e.g. 'drop ins modified: kubelet.service.d/10-flags.conf'.
+func (d *ContentDiff) String() string {
+	var parts []string
+	parts = append(parts, d.Files.summary("files")...)
+	parts = append(parts, d.Directories.summary("directories")...)
+	parts = append(parts, d.Symlinks.summary("symlinks")...)
+	parts = append(parts, d.Units.summary("units")...)
+	parts = append(parts, d.DropIns.summary("drop ins")...)
+	return strings.Join(parts, "; ")
+}
+
+func (c Changes) isEmpty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Modified) == 0
+}
+
+func (c Changes) summary(kind string) []string {
+	var parts []string
+	for _, change := range []struct {
+		verb string
+		keys []string
+	}{
+		{"added", c.Added},
+		{"removed", c.Removed},
+		{"modified", c.Modified},
+	} {
+		if len(change.keys) > 0 {
+			parts = append(parts, fmt.Sprintf("%s %s: %s", kind, change.verb, strings.Join(change.keys, ", ")))
+		}
+	}
+	return parts
+}
+
+func diff(old, new map[string]string) Changes {
+	var changes Changes
+	for key, newHash := range new {
+		oldHash, ok := old[key]
+		switch {
+		case !ok:
+			changes.Added = append(changes.Added, key)
+		case oldHash != newHash:
+			changes.Modified = append(changes.Modified, key)
+		}
+	}
+	for key := range old {
+		if _, ok := new[key]; !ok {
+			changes.Removed = append(changes.Removed, key)
+		}
+	}
+
+	sort.Strings(changes.Added)
+	sort.Strings(changes.Removed)
+	sort.Strings(changes.Modified)
+	return changes
+}
+
+func hash(values ...[]byte) string {
+	h := sha256.New()
+	for _, value := range values {
+		// write the length to separate the values unambiguously
+		_, _ = fmt.Fprintf(h, "%d:", len(value))
+		_, _ = h.Write(value)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// reportChanges compares the content of the given generator input with the content of the last reconciliation,
+// which is stored in the AnnotationContentHashes annotation. If anything changed, the changes are logged and reported
+// as an event, and the annotation is updated. Nothing is reported for the first reconciliation.
+func (a *Actuator) reportChanges(ctx context.Context, config *extensionsv1alpha1.OperatingSystemConfig, data *commonosgenerator.OperatingSystemConfig) error {
+	hashes := ContentHashesForConfig(data)
+	encoded, err := json.Marshal(hashes)
+	if err != nil {
+		return err
+	}
+
+	previous, ok := config.Annotations[AnnotationContentHashes]
+	if ok && previous == string(encoded) {
+		return nil
+	}
+
+	if ok {
+		old := &ContentHashes{}
+		if err := json.Unmarshal([]byte(previous), old); err != nil {
+			a.logger.Error(err, "Could not decode content hashes of last reconciliation", "osc", config.Name, "namespace", config.Namespace)
+		} else if contentDiff := hashes.Diff(old); !contentDiff.IsEmpty() {
+			a.logger.Info("Operating system config changed", "osc", config.Name, "namespace", config.Namespace, "changes", contentDiff.String())
+			if a.recorder != nil {
+				a.recorder.Eventf(config, corev1.EventTypeNormal, EventReasonContentChanged, "Operating system config changed: %s", contentDiff)
+			}
+		}
+	}
+
+	patch := runtimeclient.MergeFrom(config.DeepCopy())
+	if config.Annotations == nil {
+		config.Annotations = make(map[string]string)
+	}
+	config.Annotations[AnnotationContentHashes] = string(encoded)
+	return a.client.Patch(ctx, config, patch)
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actuator_test
+
+import (
+	"context"
+
+	. "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/actuator"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+var _ = Describe("Diff", func() {
+	Describe("#Diff", func() {
+		var (
+			permissions = int32(0644)
+			config      *generator.OperatingSystemConfig
+		)
+
+		BeforeEach(func() {
+			config = &generator.OperatingSystemConfig{
+				Files: []*generator.File{
+					{Path: "/etc/foo", Content: []byte("foo")},
+					{Path: "/etc/bar", Content: []byte("bar")},
+				},
+				Directories: []*generator.Directory{
+					{Path: "/var/lib/foo"},
+				},
+				Symlinks: []*generator.Symlink{
+					{Path: "/usr/bin/foo", Target: "/opt/bin/foo"},
+				},
+				Units: []*generator.Unit{
+					{Name: "foo.service", Content: []byte("foo"), DropIns: []*generator.DropIn{{Name: "10-foo.conf", Content: []byte("foo")}}},
+				},
+			}
+		})
+
+		It("should be empty for the same config", func() {
+			Expect(ContentHashesForConfig(config).Diff(ContentHashesForConfig(config)).IsEmpty()).To(BeTrue())
+		})
+
+		It("should report added, removed and modified paths and units", func() {
+			old := ContentHashesForConfig(config)
+
+			config.Files[0].Permissions = &permissions
+			config.Files[1] = &generator.File{Path: "/etc/baz", Content: []byte("baz")}
+			config.Units[0].DropIns[0].Content = []byte("bar")
+			config.Units = append(config.Units, &generator.Unit{Name: "bar.service"})
+
+			diff := ContentHashesForConfig(config).Diff(old)
+			Expect(diff.IsEmpty()).To(BeFalse())
+			Expect(diff.Files).To(Equal(Changes{Added: []string{"/etc/baz"}, Removed: []string{"/etc/bar"}, Modified: []string{"/etc/foo"}}))
+			Expect(diff.Units).To(Equal(Changes{Added: []string{"bar.service"}}))
+			Expect(diff.DropIns).To(Equal(Changes{Modified: []string{"foo.service.d/10-foo.conf"}}))
+			Expect(diff.String()).To(Equal("files added: /etc/baz; files removed: /etc/bar; files modified: /etc/foo; units added: bar.service; drop ins modified: foo.service.d/10-foo.conf"))
+		})
+
+		It("should report added, removed and modified directories and symlinks", func() {
+			old := ContentHashesForConfig(config)
+
+			config.Directories[0].Owner = "foo"
+			config.Directories = append(config.Directories, &generator.Directory{Path: "/var/lib/bar"})
+			config.Symlinks[0].Target = "/opt/bin/bar"
+
+			diff := ContentHashesForConfig(config).Diff(old)
+			Expect(diff.Directories).To(Equal(Changes{Added: []string{"/var/lib/bar"}, Modified: []string{"/var/lib/foo"}}))
+			Expect(diff.Symlinks).To(Equal(Changes{Modified: []string{"/usr/bin/foo"}}))
+			Expect(diff.String()).To(Equal("directories added: /var/lib/bar; directories modified: /var/lib/foo; symlinks modified: /usr/bin/foo"))
+
+			config.Symlinks = nil
+			Expect(ContentHashesForConfig(config).Diff(old).Symlinks).To(Equal(Changes{Removed: []string{"/usr/bin/foo"}}))
+		})
+	})
+
+	Describe("#Reconcile", func() {
+		var (
+			osc      *extensionsv1alpha1.OperatingSystemConfig
+			recorder *record.FakeRecorder
+			a        *Actuator
+		)
+
+		BeforeEach(func() {
+			osc = &extensionsv1alpha1.OperatingSystemConfig{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "shoot--foo--bar", Name: "foo"},
+				Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+					Files: []extensionsv1alpha1.File{
+						{Path: "/etc/foo", Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: "foo"}}},
+					},
+				},
+			}
+			recorder = record.NewFakeRecorder(10)
+			a = newActuator(&staticGenerator{[]byte("#cloud-config\n")}, Options{Recorder: recorder}, osc)
+		})
+
+		It("should only store the content hashes on the first reconciliation", func() {
+			_, _, _, err := a.Reconcile(context.TODO(), osc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(osc.Annotations).To(HaveKey(AnnotationContentHashes))
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("should not report anything if nothing changed", func() {
+			_, _, _, err := a.Reconcile(context.TODO(), osc)
+			Expect(err).NotTo(HaveOccurred())
+			_, _, _, err = a.Reconcile(context.TODO(), osc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("should report the changes as an event", func() {
+			_, _, _, err := a.Reconcile(context.TODO(), osc)
+			Expect(err).NotTo(HaveOccurred())
+
+			osc.Spec.Files[0].Content.Inline.Data = "bar"
+			osc.Spec.Units = []extensionsv1alpha1.Unit{{Name: "foo.service"}}
+			_, _, _, err = a.Reconcile(context.TODO(), osc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).To(Receive(Equal("Normal " + EventReasonContentChanged + " Operating system config changed: files modified: /etc/foo; units added: foo.service")))
+		})
+	})
+})
@@ -0,0 +1,84 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actuator
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/cloudinit"
+)
+
+// SelfExtractingScriptUserDataPath is the path on the worker where a self-extracting script writes the original user
+// data to before executing it.
+const SelfExtractingScriptUserDataPath = "/var/lib/cloud-config-bootstrap/user-data"
+
+const (
+	scriptPrefix       = "#!"
+	base64LineLength   = 76
+	selfExtractingHead = `#!/bin/bash -eu
+umask 077
+mkdir -p "$(dirname %[1]s)"
+base64 -d <<'EOF' | gunzip > %[1]s
+`
+	selfExtractingTail = `EOF
+chmod 0700 %[1]s
+exec %[1]s
+`
+)
+
+// fitUserData makes sure that the given user data does not exceed the given limit in bytes. If it does, and the user
+// data is a script, it is replaced by a self-extracting script containing the compressed user data. A limit <= 0 means
+// that the size of the user data is not limited.
+func fitUserData(userData []byte, limit int) ([]byte, error) {
+	if limit <= 0 || len(userData) <= limit {
+		return userData, nil
+	}
+
+	if !bytes.HasPrefix(userData, []byte(scriptPrefix)) {
+		return nil, fmt.Errorf("cloud config has %d bytes and exceeds the user data limit of %d bytes, and cannot be compressed as it is not a script", len(userData), limit)
+	}
+
+	script, err := SelfExtractingScript(userData)
+	if err != nil {
+		return nil, fmt.Errorf("could not compress cloud config: %v", err)
+	}
+	if len(script) > limit {
+		return nil, fmt.Errorf("cloud config has %d bytes (%d bytes compressed) and exceeds the user data limit of %d bytes", len(userData), len(script), limit)
+	}
+	return script, nil
+}
+
+// SelfExtractingScript returns a bash script containing the given gzip compressed and base64 encoded script. When
+// executed, it extracts the script to SelfExtractingScriptUserDataPath and executes it.
+func SelfExtractingScript(script []byte) ([]byte, error) {
+	compressed, err := cloudinit.GZIPFileCodec.Encode(script)
+	if err != nil {
+		return nil, err
+	}
+	encoded := base64.StdEncoding.EncodeToString(compressed)
+
+	var out strings.Builder
+	out.WriteString(fmt.Sprintf(selfExtractingHead, SelfExtractingScriptUserDataPath))
+	for len(encoded) > base64LineLength {
+		out.WriteString(encoded[:base64LineLength] + "\n")
+		encoded = encoded[base64LineLength:]
+	}
+	out.WriteString(encoded + "\n")
+	out.WriteString(fmt.Sprintf(selfExtractingTail, SelfExtractingScriptUserDataPath))
+	return []byte(out.String()), nil
+}
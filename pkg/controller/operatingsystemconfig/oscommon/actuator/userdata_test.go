@@ -0,0 +1,131 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actuator_test
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+
+	. "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/actuator"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/cloudinit"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	extensionsclient "github.com/gardener/gardener/pkg/client/extensions/clientset/versioned/scheme"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type staticGenerator struct {
+	cloudConfig []byte
+}
+
+func (g *staticGenerator) Generate(*generator.OperatingSystemConfig) ([]byte, *string, error) {
+	return g.cloudConfig, nil, nil
+}
+
+// extract returns the original script contained in the given self-extracting script.
+func extract(script []byte) []byte {
+	lines := strings.Split(string(script), "\n")
+	var encoded strings.Builder
+	for _, line := range lines[4:] {
+		if line == "EOF" {
+			break
+		}
+		encoded.WriteString(line)
+	}
+
+	compressed, err := base64.StdEncoding.DecodeString(encoded.String())
+	Expect(err).NotTo(HaveOccurred())
+	original, err := cloudinit.GZIPFileCodec.Decode(compressed)
+	Expect(err).NotTo(HaveOccurred())
+	return original
+}
+
+// newActuator returns an actuator using the given generator and options whose client contains the given config.
+func newActuator(gen generator.Generator, opts Options, osc *extensionsv1alpha1.OperatingSystemConfig) *Actuator {
+	a := NewActuatorWithOptions("test", gen, opts).(*Actuator)
+	Expect(a.InjectClient(fake.NewFakeClientWithScheme(extensionsclient.Scheme, osc.DeepCopy()))).To(Succeed())
+	return a
+}
+
+var _ = Describe("User data", func() {
+	var (
+		osc         *extensionsv1alpha1.OperatingSystemConfig
+		largeScript = []byte("#!/bin/bash\n" + strings.Repeat("echo 'hello world'\n", 1000))
+	)
+
+	BeforeEach(func() {
+		osc = &extensionsv1alpha1.OperatingSystemConfig{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shoot--foo--bar", Name: "foo"},
+			Spec:       extensionsv1alpha1.OperatingSystemConfigSpec{Purpose: extensionsv1alpha1.OperatingSystemConfigPurposeProvision},
+		}
+	})
+
+	Describe("#SelfExtractingScript", func() {
+		It("should contain the compressed script", func() {
+			script, err := SelfExtractingScript(largeScript)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(script)).To(HavePrefix("#!/bin/bash -eu\n"))
+			Expect(string(script)).To(HaveSuffix("exec " + SelfExtractingScriptUserDataPath + "\n"))
+			Expect(extract(script)).To(Equal(largeScript))
+		})
+	})
+
+	Describe("#Reconcile", func() {
+		It("should not change cloud configs within the limit", func() {
+			a := newActuator(&staticGenerator{largeScript}, Options{UserDataLimit: len(largeScript)}, osc)
+
+			cloudConfig, _, _, err := a.Reconcile(context.TODO(), osc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cloudConfig).To(Equal(largeScript))
+		})
+
+		It("should compress scripts exceeding the limit", func() {
+			a := newActuator(&staticGenerator{largeScript}, Options{UserDataLimit: 1024}, osc)
+
+			cloudConfig, _, _, err := a.Reconcile(context.TODO(), osc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(cloudConfig)).To(BeNumerically("<=", 1024))
+			Expect(extract(cloudConfig)).To(Equal(largeScript))
+		})
+
+		It("should not limit cloud configs for reconciliation", func() {
+			osc.Spec.Purpose = extensionsv1alpha1.OperatingSystemConfigPurposeReconcile
+			a := newActuator(&staticGenerator{largeScript}, Options{UserDataLimit: 100}, osc)
+
+			cloudConfig, _, _, err := a.Reconcile(context.TODO(), osc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cloudConfig).To(Equal(largeScript))
+		})
+
+		It("should fail if the compressed script still exceeds the limit", func() {
+			a := newActuator(&staticGenerator{largeScript}, Options{UserDataLimit: 100}, osc)
+
+			_, _, _, err := a.Reconcile(context.TODO(), osc)
+			Expect(err).To(MatchError(ContainSubstring("exceeds the user data limit of 100 bytes")))
+		})
+
+		It("should fail if a cloud config exceeding the limit is not a script", func() {
+			a := newActuator(&staticGenerator{[]byte("#cloud-config\nruncmd: []\n")}, Options{UserDataLimit: 10}, osc)
+
+			_, _, _, err := a.Reconcile(context.TODO(), osc)
+			Expect(err).To(MatchError(ContainSubstring("cannot be compressed as it is not a script")))
+		})
+	})
+})
@@ -0,0 +1,147 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actuator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path"
+	"strings"
+	"time"
+
+	commonosgenerator "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+
+	"github.com/coreos/go-systemd/unit"
+	"github.com/go-logr/logr"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// ValidateGeneratorConfig validates the given generator input. It rejects duplicate file paths, unit names and drop in
+// names as well as units and drop ins which cannot be parsed by the systemd unit deserializer.
+func ValidateGeneratorConfig(config *commonosgenerator.OperatingSystemConfig) error {
+	var (
+		errs  []error
+		paths = make(map[string]bool, len(config.Files))
+		units = make(map[string]bool, len(config.Units))
+	)
+
+	for _, file := range config.Files {
+		if paths[file.Path] {
+			errs = append(errs, fmt.Errorf("duplicate file path %q", file.Path))
+		}
+		paths[file.Path] = true
+	}
+
+	for _, u := range config.Units {
+		if units[u.Name] {
+			errs = append(errs, fmt.Errorf("duplicate unit name %q", u.Name))
+		}
+		units[u.Name] = true
+
+		if err := validateUnitContent(u.Content); err != nil {
+			errs = append(errs, fmt.Errorf("invalid unit %q: %v", u.Name, err))
+		}
+
+		dropIns := make(map[string]bool, len(u.DropIns))
+		for _, dropIn := range u.DropIns {
+			if dropIns[dropIn.Name] {
+				errs = append(errs, fmt.Errorf("duplicate drop in name %q of unit %q", dropIn.Name, u.Name))
+			}
+			dropIns[dropIn.Name] = true
+
+			if err := validateUnitContent(dropIn.Content); err != nil {
+				errs = append(errs, fmt.Errorf("invalid drop in %q of unit %q: %v", dropIn.Name, u.Name, err))
+			}
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+func validateUnitContent(content []byte) error {
+	// units without content are only enabled or started
+	if len(content) == 0 {
+		return nil
+	}
+
+	// the deserializer silently skips everything before the first section
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if !strings.HasPrefix(line, "[") {
+			return fmt.Errorf("line %q is not part of a section", line)
+		}
+		break
+	}
+
+	_, err := unit.Deserialize(bytes.NewReader(content))
+	return err
+}
+
+// ScriptValidationTimeout is the maximum duration of the syntax check of a bash script.
+const ScriptValidationTimeout = 10 * time.Second
+
+// ValidateScript checks the syntax of the given cloud config if it is a bash script, i.e. if it starts with a bash
+// shebang. The check is skipped (and logged with the given logger) if no bash executable is available. It fails if it
+// does not finish within the ScriptValidationTimeout or before the given context is done.
+func ValidateScript(ctx context.Context, logger logr.Logger, script []byte) error {
+	if !isBashScript(script) {
+		return nil
+	}
+
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		logger.Info("Skipping syntax check of bash script, no bash executable found", "error", err.Error())
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ScriptValidationTimeout)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, bash, "-n")
+	cmd.Stdin = bytes.NewReader(script)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("could not check syntax of bash script: %v", ctx.Err())
+		}
+		if _, ok := err.(*exec.ExitError); ok {
+			return fmt.Errorf("invalid bash script: %s", strings.TrimSpace(stderr.String()))
+		}
+		return err
+	}
+	return nil
+}
+
+func isBashScript(script []byte) bool {
+	firstLine := string(script)
+	if i := strings.IndexByte(firstLine, '\n'); i >= 0 {
+		firstLine = firstLine[:i]
+	}
+	if !strings.HasPrefix(firstLine, "#!") {
+		return false
+	}
+
+	interpreter := strings.Fields(firstLine[2:])
+	if len(interpreter) > 1 && path.Base(interpreter[0]) == "env" {
+		interpreter = interpreter[1:]
+	}
+	return len(interpreter) > 0 && path.Base(interpreter[0]) == "bash"
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actuator_test
+
+import (
+	"context"
+
+	. "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/actuator"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Validation", func() {
+	Describe("#ValidateGeneratorConfig", func() {
+		var config *generator.OperatingSystemConfig
+
+		BeforeEach(func() {
+			config = &generator.OperatingSystemConfig{
+				Files: []*generator.File{
+					{Path: "/etc/foo", Content: []byte("foo")},
+				},
+				Units: []*generator.Unit{
+					{
+						Name:    "foo.service",
+						Content: []byte("[Unit]\nDescription=foo\n[Service]\nExecStart=/bin/foo\n"),
+						DropIns: []*generator.DropIn{{Name: "10-foo.conf", Content: []byte("[Service]\nRestart=always\n")}},
+					},
+					{Name: "bar.service"},
+				},
+			}
+		})
+
+		It("should accept a valid config", func() {
+			Expect(ValidateGeneratorConfig(config)).To(Succeed())
+		})
+
+		It("should reject duplicate file paths, unit names and drop in names", func() {
+			config.Files = append(config.Files, &generator.File{Path: "/etc/foo"})
+			config.Units = append(config.Units, &generator.Unit{Name: "bar.service"})
+			config.Units[0].DropIns = append(config.Units[0].DropIns, &generator.DropIn{Name: "10-foo.conf"})
+
+			err := ValidateGeneratorConfig(config)
+			Expect(err).To(MatchError(ContainSubstring(`duplicate file path "/etc/foo"`)))
+			Expect(err).To(MatchError(ContainSubstring(`duplicate unit name "bar.service"`)))
+			Expect(err).To(MatchError(ContainSubstring(`duplicate drop in name "10-foo.conf" of unit "foo.service"`)))
+		})
+
+		It("should reject units which cannot be parsed", func() {
+			config.Units[0].Content = []byte("[Unit\nDescription=foo\n")
+
+			Expect(ValidateGeneratorConfig(config)).To(MatchError(ContainSubstring(`invalid unit "foo.service"`)))
+		})
+
+		It("should reject drop ins with options outside of a section", func() {
+			config.Units[0].DropIns[0].Content = []byte("Restart=always\n")
+
+			Expect(ValidateGeneratorConfig(config)).To(MatchError(ContainSubstring(`invalid drop in "10-foo.conf" of unit "foo.service": line "Restart=always" is not part of a section`)))
+		})
+	})
+
+	Describe("#ValidateScript", func() {
+		var logger = log.Log.WithName("test")
+
+		It("should accept valid bash scripts", func() {
+			Expect(ValidateScript(context.TODO(), logger, []byte("#!/bin/bash\nif true; then\n  echo foo\nfi\n"))).To(Succeed())
+		})
+
+		It("should reject bash scripts with syntax errors", func() {
+			Expect(ValidateScript(context.TODO(), logger, []byte("#!/usr/bin/env bash\nif true; then\n  echo foo\n"))).To(MatchError(ContainSubstring("invalid bash script")))
+		})
+
+		It("should fail if the given context is done", func() {
+			ctx, cancel := context.WithCancel(context.TODO())
+			cancel()
+			Expect(ValidateScript(ctx, logger, []byte("#!/bin/bash\necho foo\n"))).To(MatchError(ContainSubstring("could not check syntax of bash script")))
+		})
+
+		It("should ignore cloud configs which are not bash scripts", func() {
+			Expect(ValidateScript(context.TODO(), logger, []byte("#cloud-config\nruncmd: [\n"))).To(Succeed())
+			Expect(ValidateScript(context.TODO(), logger, []byte("#!/usr/bin/python\nif True:\n"))).To(Succeed())
+		})
+	})
+
+	Describe("#Reconcile", func() {
+		var osc *extensionsv1alpha1.OperatingSystemConfig
+
+		BeforeEach(func() {
+			osc = &extensionsv1alpha1.OperatingSystemConfig{ObjectMeta: metav1.ObjectMeta{Namespace: "shoot--foo--bar", Name: "foo"}}
+		})
+
+		It("should fail for duplicate units", func() {
+			osc.Spec.Units = []extensionsv1alpha1.Unit{{Name: "foo.service"}, {Name: "foo.service"}}
+			a := newActuator(&staticGenerator{[]byte("#cloud-config\n")}, Options{}, osc)
+
+			_, _, _, err := a.Reconcile(context.TODO(), osc)
+			Expect(err).To(MatchError(ContainSubstring(`invalid operating system config: duplicate unit name "foo.service"`)))
+		})
+
+		It("should fail for invalid scripts", func() {
+			a := newActuator(&staticGenerator{[]byte("#!/bin/bash\nfi\n")}, Options{}, osc)
+
+			_, _, _, err := a.Reconcile(context.TODO(), osc)
+			Expect(err).To(MatchError(ContainSubstring("invalid cloud config: invalid bash script")))
+		})
+	})
+})
@@ -32,13 +32,19 @@ type AddOptions struct {
 	Controller controller.Options
 	// IgnoreOperationAnnotation specifies whether to ignore the operation annotation or not.
 	IgnoreOperationAnnotation bool
+	// UserDataLimit is the maximum size of the generated cloud configs in bytes. A limit <= 0 means that the size is not
+	// limited.
+	UserDataLimit int
 }
 
 // AddToManagerWithOptions adds a controller with the given Options to the given manager.
 // The opts.Reconciler is being set with a newly instantiated actuator.
 func AddToManagerWithOptions(mgr manager.Manager, os string, generator generator.Generator, opts AddOptions) error {
 	return operatingsystemconfig.Add(mgr, operatingsystemconfig.AddArgs{
-		Actuator:          actuator.NewActuator(os, generator),
+		Actuator: actuator.NewActuatorWithOptions(os, generator, actuator.Options{
+			UserDataLimit: opts.UserDataLimit,
+			Recorder:      mgr.GetEventRecorderFor(os + "-" + operatingsystemconfig.ControllerName),
+		}),
 		Predicates:        operatingsystemconfig.DefaultPredicates(opts.IgnoreOperationAnnotation),
 		Types:             []string{os},
 		ControllerOptions: opts.Controller,
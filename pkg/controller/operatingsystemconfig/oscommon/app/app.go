@@ -45,6 +45,8 @@ func NewControllerCommand(ctx context.Context, osName string, generator generato
 
 		reconcileOpts = &controllercmd.ReconcilerOptions{}
 
+		userDataOpts = &oscommoncmd.UserDataOptions{}
+
 		controllerSwitches = oscommoncmd.SwitchOptions(osName, generator)
 
 		aggOption = controllercmd.NewOptionAggregator(
@@ -52,6 +54,7 @@ func NewControllerCommand(ctx context.Context, osName string, generator generato
 			mgrOpts,
 			ctrlOpts,
 			reconcileOpts,
+			userDataOpts,
 			controllerSwitches,
 		)
 	)
@@ -83,6 +86,8 @@ func NewControllerCommand(ctx context.Context, osName string, generator generato
 
 			reconcileOpts.Completed().Apply(&oscommon.DefaultAddOptions.IgnoreOperationAnnotation)
 
+			userDataOpts.Completed().Apply(&oscommon.DefaultAddOptions.UserDataLimit)
+
 			if err := controllerSwitches.Completed().AddToManager(mgr); err != nil {
 				controllercmd.LogErrAndExit(err, "Could not add controller to manager")
 			}
@@ -0,0 +1,165 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudconfig
+
+import (
+	"encoding/base64"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Header is the first line of every cloud-config.
+	Header = "#cloud-config"
+	// DefaultUnitsPath is the default path where to store units at.
+	DefaultUnitsPath = "/etc/systemd/system"
+	// ContainerdUnitName is the name of the containerd unit which is enabled if the CRI is containerd.
+	ContainerdUnitName = "containerd.service"
+
+	encodingB64                 = "b64"
+	defaultFilePermissions      = "0644"
+	defaultDirectoryPermissions = "0755"
+)
+
+// CloudConfig is the subset of a cloud-config which is required to represent an OperatingSystemConfig.
+type CloudConfig struct {
+	WriteFiles []WriteFile `json:"write_files,omitempty"`
+	RunCmd     []string    `json:"runcmd,omitempty"`
+}
+
+// WriteFile is a file to be written by cloud-init.
+type WriteFile struct {
+	Path        string `json:"path"`
+	Content     string `json:"content"`
+	Encoding    string `json:"encoding,omitempty"`
+	Owner       string `json:"owner,omitempty"`
+	Permissions string `json:"permissions,omitempty"`
+	Append      bool   `json:"append,omitempty"`
+}
+
+// CloudConfigGenerator generates standard cloud-configs which can be processed by cloud-init without any OS specific
+// template. Units and their drop ins are written as files, and are enabled and (re)started by `runcmd` commands.
+// Directories and symlinks are created by `runcmd` commands before the units are started.
+type CloudConfigGenerator struct {
+	unitsPath string
+	cmd       string
+}
+
+// NewCloudConfigGenerator creates a new CloudConfigGenerator with the given units path. The given command is used to
+// apply a reconciled config; it is formatted with the path of the config on the worker.
+func NewCloudConfigGenerator(unitsPath string, cmd string) *CloudConfigGenerator {
+	return &CloudConfigGenerator{
+		unitsPath: unitsPath,
+		cmd:       cmd,
+	}
+}
+
+// Generate generates a cloud-config from the given OperatingSystemConfig.
+func (g *CloudConfigGenerator) Generate(data *generator.OperatingSystemConfig) ([]byte, *string, error) {
+	config := CloudConfig{}
+
+	for _, file := range data.Files {
+		writeFile := newWriteFile(file.Path, file.Content, permissions(file.Permissions, defaultFilePermissions))
+		writeFile.Owner = owner(file.Owner, file.Group)
+		writeFile.Append = file.Append
+		config.WriteFiles = append(config.WriteFiles, writeFile)
+	}
+
+	var unitNames []string
+	for _, unit := range data.Units {
+		if unit.Content != nil {
+			config.WriteFiles = append(config.WriteFiles, newWriteFile(path.Join(g.unitsPath, unit.Name), unit.Content, defaultFilePermissions))
+		}
+		for _, dropIn := range unit.DropIns {
+			config.WriteFiles = append(config.WriteFiles, newWriteFile(path.Join(g.unitsPath, unit.Name+".d", dropIn.Name), dropIn.Content, defaultFilePermissions))
+		}
+		unitNames = append(unitNames, unit.Name)
+	}
+
+	for _, directory := range data.Directories {
+		config.RunCmd = append(config.RunCmd, fmt.Sprintf("mkdir -p %[1]s && chmod %[2]s %[1]s && chown %[3]s %[1]s",
+			quote(directory.Path), permissions(directory.Permissions, defaultDirectoryPermissions), owner(directory.Owner, directory.Group)))
+	}
+	for _, symlink := range data.Symlinks {
+		config.RunCmd = append(config.RunCmd, fmt.Sprintf("mkdir -p %s && ln -sfn %s %s", quote(path.Dir(symlink.Path)), quote(symlink.Target), quote(symlink.Path)))
+	}
+
+	if data.Bootstrap && isContainerdEnabled(data.CRI) && !contains(unitNames, ContainerdUnitName) {
+		unitNames = append([]string{ContainerdUnitName}, unitNames...)
+	}
+
+	config.RunCmd = append(config.RunCmd, "systemctl daemon-reload")
+	for _, unitName := range unitNames {
+		config.RunCmd = append(config.RunCmd, fmt.Sprintf("systemctl enable %[1]s && systemctl restart %[1]s", unitName))
+	}
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not marshal cloud-config: %v", err)
+	}
+
+	var cmd *string
+	if data.Path != nil {
+		c := fmt.Sprintf(g.cmd, *data.Path)
+		cmd = &c
+	}
+
+	return append([]byte(Header+"\n"), out...), cmd, nil
+}
+
+func newWriteFile(filePath string, content []byte, permissions string) WriteFile {
+	return WriteFile{
+		Path:        filePath,
+		Content:     base64.StdEncoding.EncodeToString(content),
+		Encoding:    encodingB64,
+		Owner:       owner("", ""),
+		Permissions: permissions,
+	}
+}
+
+func permissions(p *int32, defaultPermissions string) string {
+	if p == nil {
+		return defaultPermissions
+	}
+	return fmt.Sprintf("%04o", *p)
+}
+
+func owner(user, group string) string {
+	return generator.OwnerOrDefault(user) + ":" + generator.OwnerOrDefault(group)
+}
+
+// quote quotes the given string for a shell.
+func quote(s string) string {
+	return "'" + strings.Replace(s, "'", `'"'"'`, -1) + "'"
+}
+
+func isContainerdEnabled(cri *extensionsv1alpha1.CRIConfig) bool {
+	return cri != nil && cri.Name == extensionsv1alpha1.CRINameContainerD
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
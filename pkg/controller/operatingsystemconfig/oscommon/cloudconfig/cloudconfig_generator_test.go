@@ -0,0 +1,130 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudconfig_test
+
+import (
+	. "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/cloudconfig"
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CloudConfigGenerator", func() {
+	var (
+		g           *CloudConfigGenerator
+		permissions = int32(0600)
+		path        = "/var/lib/osc/config"
+		osc         *generator.OperatingSystemConfig
+	)
+
+	BeforeEach(func() {
+		g = NewCloudConfigGenerator(DefaultUnitsPath, "/usr/bin/cloud-init single --file %s")
+		osc = &generator.OperatingSystemConfig{
+			CRI: &extensionsv1alpha1.CRIConfig{Name: extensionsv1alpha1.CRINameContainerD},
+			Files: []*generator.File{
+				{Path: "/foo", Content: []byte("bar"), Permissions: &permissions},
+				{Path: "/baz", Content: []byte("qux")},
+			},
+			Units: []*generator.Unit{
+				{
+					Name:    "docker.service",
+					Content: []byte("unit"),
+					DropIns: []*generator.DropIn{{Name: "10-docker-opts.conf", Content: []byte("override")}},
+				},
+			},
+		}
+	})
+
+	It("should render a bootstrap cloud-config", func() {
+		osc.Bootstrap = true
+
+		cloudConfig, cmd, err := g.Generate(osc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cmd).To(BeNil())
+		Expect(string(cloudConfig)).To(Equal(`#cloud-config
+runcmd:
+- systemctl daemon-reload
+- systemctl enable containerd.service && systemctl restart containerd.service
+- systemctl enable docker.service && systemctl restart docker.service
+write_files:
+- content: YmFy
+  encoding: b64
+  owner: root:root
+  path: /foo
+  permissions: "0600"
+- content: cXV4
+  encoding: b64
+  owner: root:root
+  path: /baz
+  permissions: "0644"
+- content: dW5pdA==
+  encoding: b64
+  owner: root:root
+  path: /etc/systemd/system/docker.service
+  permissions: "0644"
+- content: b3ZlcnJpZGU=
+  encoding: b64
+  owner: root:root
+  path: /etc/systemd/system/docker.service.d/10-docker-opts.conf
+  permissions: "0644"
+`))
+	})
+
+	It("should render a reconcile cloud-config and the command to apply it", func() {
+		osc.Files = nil
+		osc.Units[0].Content = nil
+		osc.Path = &path
+
+		cloudConfig, cmd, err := g.Generate(osc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*cmd).To(Equal("/usr/bin/cloud-init single --file /var/lib/osc/config"))
+		Expect(string(cloudConfig)).To(Equal(`#cloud-config
+runcmd:
+- systemctl daemon-reload
+- systemctl enable docker.service && systemctl restart docker.service
+write_files:
+- content: b3ZlcnJpZGU=
+  encoding: b64
+  owner: root:root
+  path: /etc/systemd/system/docker.service.d/10-docker-opts.conf
+  permissions: "0644"
+`))
+	})
+
+	It("should render ownership, appended files, directories and symlinks", func() {
+		osc.Files = []*generator.File{{Path: "/etc/foo", Content: []byte("bar"), Owner: "foo", Append: true}}
+		osc.Units = nil
+		osc.Directories = []*generator.Directory{{Path: "/var/lib/foo", Permissions: &permissions, Owner: "foo", Group: "bar"}}
+		osc.Symlinks = []*generator.Symlink{{Path: "/usr/local/bin/foo", Target: "/opt/foo/bin/foo"}}
+
+		cloudConfig, _, err := g.Generate(osc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(cloudConfig)).To(Equal(`#cloud-config
+runcmd:
+- mkdir -p '/var/lib/foo' && chmod 0600 '/var/lib/foo' && chown foo:bar '/var/lib/foo'
+- mkdir -p '/usr/local/bin' && ln -sfn '/opt/foo/bin/foo' '/usr/local/bin/foo'
+- systemctl daemon-reload
+write_files:
+- append: true
+  content: YmFy
+  encoding: b64
+  owner: foo:root
+  path: /etc/foo
+  permissions: "0644"
+`))
+	})
+})
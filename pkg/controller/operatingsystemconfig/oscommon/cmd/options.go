@@ -15,14 +15,20 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/gardener/gardener-extensions/pkg/controller/cmd"
 	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig"
 	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon"
 	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
 
+	"github.com/spf13/pflag"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
+// UserDataLimitFlag is the name of the command line flag to specify the maximum size of the generated cloud configs.
+const UserDataLimitFlag = "user-data-limit"
+
 // SwitchOptions are the cmd.SwitchOptions for the provider controllers.
 func SwitchOptions(os string, generator generator.Generator) *cmd.SwitchOptions {
 	return cmd.NewSwitchOptions(
@@ -31,3 +37,41 @@ func SwitchOptions(os string, generator generator.Generator) *cmd.SwitchOptions
 		}),
 	)
 }
+
+// UserDataOptions are command line options for the generated user data.
+type UserDataOptions struct {
+	// UserDataLimit is the maximum size of the generated cloud configs in bytes.
+	UserDataLimit int
+
+	config *UserDataConfig
+}
+
+// AddFlags implements Flagger.AddFlags.
+func (u *UserDataOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.IntVar(&u.UserDataLimit, UserDataLimitFlag, u.UserDataLimit, "Maximum size of the generated cloud configs for provisioning in bytes, e.g. the user data limit of the cloud provider. Larger scripts are compressed. 0 means unlimited.")
+}
+
+// Complete implements Completer.Complete.
+func (u *UserDataOptions) Complete() error {
+	if u.UserDataLimit < 0 {
+		return fmt.Errorf("user data limit must not be negative, got %d", u.UserDataLimit)
+	}
+	u.config = &UserDataConfig{u.UserDataLimit}
+	return nil
+}
+
+// Completed returns the completed UserDataConfig. Only call this if `Complete` was successful.
+func (u *UserDataOptions) Completed() *UserDataConfig {
+	return u.config
+}
+
+// UserDataConfig is a completed user data configuration.
+type UserDataConfig struct {
+	// UserDataLimit is the maximum size of the generated cloud configs in bytes.
+	UserDataLimit int
+}
+
+// Apply sets the values of this UserDataConfig in the given limit.
+func (u *UserDataConfig) Apply(limit *int) {
+	*limit = u.UserDataLimit
+}
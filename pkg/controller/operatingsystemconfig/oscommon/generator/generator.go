@@ -30,6 +30,28 @@ type File struct {
 	Path        string
 	Content     []byte
 	Permissions *int32
+	// Owner is the name of the user owning the file. Defaults to root.
+	Owner string
+	// Group is the name of the group owning the file. Defaults to root.
+	Group string
+	// Append specifies whether the content is appended to an existing file instead of overwriting it.
+	Append bool
+}
+
+// Directory is a directory to be created during the cloud init script.
+type Directory struct {
+	Path        string `json:"path"`
+	Permissions *int32 `json:"permissions,omitempty"`
+	// Owner is the name of the user owning the directory. Defaults to root.
+	Owner string `json:"owner,omitempty"`
+	// Group is the name of the group owning the directory. Defaults to root.
+	Group string `json:"group,omitempty"`
+}
+
+// Symlink is a symbolic link to be created during the cloud init script.
+type Symlink struct {
+	Path   string `json:"path"`
+	Target string `json:"target"`
 }
 
 // Unit is a unit to be created during the cloud init script.
@@ -47,9 +69,19 @@ type DropIn struct {
 
 // OperatingSystemConfig is the data required to create a cloud init script.
 type OperatingSystemConfig struct {
-	CRI       *extensionsv1alpha1.CRIConfig
-	Files     []*File
-	Units     []*Unit
-	Bootstrap bool
-	Path      *string
+	CRI         *extensionsv1alpha1.CRIConfig
+	Files       []*File
+	Directories []*Directory
+	Symlinks    []*Symlink
+	Units       []*Unit
+	Bootstrap   bool
+	Path        *string
+}
+
+// OwnerOrDefault returns the given owner, or root if it is empty.
+func OwnerOrDefault(owner string) string {
+	if len(owner) == 0 {
+		return "root"
+	}
+	return owner
 }
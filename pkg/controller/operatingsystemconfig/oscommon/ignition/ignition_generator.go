@@ -0,0 +1,163 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ignition
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+)
+
+// ContainerdUnitName is the name of the containerd unit which is enabled if the CRI is containerd.
+const ContainerdUnitName = "containerd.service"
+
+// IgnitionGenerator generates Ignition v3 configs, e.g. for Flatcar Container Linux or Fedora CoreOS.
+type IgnitionGenerator struct {
+	cmd string
+}
+
+// NewIgnitionGenerator creates a new IgnitionGenerator. The given command is used to apply a reconciled config; it is
+// formatted with the path of the config on the worker.
+func NewIgnitionGenerator(cmd string) *IgnitionGenerator {
+	return &IgnitionGenerator{
+		cmd: cmd,
+	}
+}
+
+// Generate generates an Ignition config from the given OperatingSystemConfig.
+// All files are overwritten if they exist, unless their content shall be appended. If the config is used to bootstrap a worker, all units are enabled so that
+// they are started on boot; the units of a reconciled config are only written.
+func (g *IgnitionGenerator) Generate(data *generator.OperatingSystemConfig) ([]byte, *string, error) {
+	config := Config{
+		Ignition: Ignition{Version: Version},
+	}
+
+	for _, directory := range data.Directories {
+		config.Storage.Directories = append(config.Storage.Directories, Directory{
+			Node: node(directory.Path, directory.Owner, directory.Group),
+			Mode: mode(directory.Permissions),
+		})
+	}
+
+	for _, file := range data.Files {
+		iFile := File{
+			Node: node(file.Path, file.Owner, file.Group),
+			Mode: mode(file.Permissions),
+		}
+		contents := FileContents{Source: dataURL(file.Content)}
+		if file.Append {
+			iFile.Append = []FileContents{contents}
+		} else {
+			iFile.Overwrite = boolPtr(true)
+			iFile.Contents = &contents
+		}
+		config.Storage.Files = append(config.Storage.Files, iFile)
+	}
+
+	for _, symlink := range data.Symlinks {
+		link := Link{
+			Node:   node(symlink.Path, "", ""),
+			Target: symlink.Target,
+		}
+		link.Overwrite = boolPtr(true)
+		config.Storage.Links = append(config.Storage.Links, link)
+	}
+
+	for _, unit := range data.Units {
+		iUnit := Unit{
+			Name: unit.Name,
+		}
+		if unit.Content != nil {
+			content := string(unit.Content)
+			iUnit.Contents = &content
+		}
+		if data.Bootstrap {
+			iUnit.Enabled = boolPtr(true)
+		}
+		for _, dropIn := range unit.DropIns {
+			content := string(dropIn.Content)
+			iUnit.Dropins = append(iUnit.Dropins, Dropin{
+				Name:     dropIn.Name,
+				Contents: &content,
+			})
+		}
+		config.Systemd.Units = append(config.Systemd.Units, iUnit)
+	}
+
+	if data.Bootstrap && isContainerdEnabled(data.CRI) && !hasUnit(data.Units, ContainerdUnitName) {
+		config.Systemd.Units = append(config.Systemd.Units, Unit{
+			Name:    ContainerdUnitName,
+			Enabled: boolPtr(true),
+		})
+	}
+
+	out, err := json.Marshal(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not marshal ignition config: %v", err)
+	}
+
+	var cmd *string
+	if data.Path != nil {
+		c := fmt.Sprintf(g.cmd, *data.Path)
+		cmd = &c
+	}
+
+	return out, cmd, nil
+}
+
+func node(path, user, group string) Node {
+	n := Node{Path: path}
+	if len(user) > 0 {
+		n.User = &NodeUser{Name: user}
+	}
+	if len(group) > 0 {
+		n.Group = &NodeUser{Name: group}
+	}
+	return n
+}
+
+func mode(permissions *int32) *int {
+	if permissions == nil {
+		return nil
+	}
+	m := int(*permissions)
+	return &m
+}
+
+func dataURL(data []byte) *string {
+	url := "data:;base64," + base64.StdEncoding.EncodeToString(data)
+	return &url
+}
+
+func isContainerdEnabled(cri *extensionsv1alpha1.CRIConfig) bool {
+	return cri != nil && cri.Name == extensionsv1alpha1.CRINameContainerD
+}
+
+func hasUnit(units []*generator.Unit, name string) bool {
+	for _, unit := range units {
+		if unit.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ignition_test
+
+import (
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+	. "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/ignition"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IgnitionGenerator", func() {
+	var (
+		g           *IgnitionGenerator
+		permissions = int32(0600)
+		path        = "/var/lib/osc/config"
+		osc         *generator.OperatingSystemConfig
+	)
+
+	BeforeEach(func() {
+		g = NewIgnitionGenerator("/usr/bin/apply-ignition %s")
+		osc = &generator.OperatingSystemConfig{
+			CRI: &extensionsv1alpha1.CRIConfig{Name: extensionsv1alpha1.CRINameContainerD},
+			Files: []*generator.File{
+				{Path: "/foo", Content: []byte("bar"), Permissions: &permissions},
+			},
+			Units: []*generator.Unit{
+				{
+					Name:    "docker.service",
+					Content: []byte("unit"),
+					DropIns: []*generator.DropIn{{Name: "10-docker-opts.conf", Content: []byte("override")}},
+				},
+			},
+		}
+	})
+
+	It("should render a bootstrap config with enabled units", func() {
+		osc.Bootstrap = true
+
+		config, cmd, err := g.Generate(osc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cmd).To(BeNil())
+		Expect(config).To(MatchJSON(`{
+  "ignition": {"version": "3.0.0"},
+  "storage": {
+    "files": [
+      {"path": "/foo", "overwrite": true, "mode": 384, "contents": {"source": "data:;base64,YmFy"}}
+    ]
+  },
+  "systemd": {
+    "units": [
+      {"name": "docker.service", "enabled": true, "contents": "unit", "dropins": [{"name": "10-docker-opts.conf", "contents": "override"}]},
+      {"name": "containerd.service", "enabled": true}
+    ]
+  }
+}`))
+	})
+
+	It("should render a reconcile config and the command to apply it", func() {
+		osc.Path = &path
+
+		config, cmd, err := g.Generate(osc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*cmd).To(Equal("/usr/bin/apply-ignition /var/lib/osc/config"))
+		Expect(config).To(MatchJSON(`{
+  "ignition": {"version": "3.0.0"},
+  "storage": {
+    "files": [
+      {"path": "/foo", "overwrite": true, "mode": 384, "contents": {"source": "data:;base64,YmFy"}}
+    ]
+  },
+  "systemd": {
+    "units": [
+      {"name": "docker.service", "contents": "unit", "dropins": [{"name": "10-docker-opts.conf", "contents": "override"}]}
+    ]
+  }
+}`))
+	})
+
+	It("should render ownership, appended files, directories and symlinks", func() {
+		osc.Files = []*generator.File{{Path: "/etc/foo", Content: []byte("bar"), Owner: "foo", Append: true}}
+		osc.Units = nil
+		osc.Directories = []*generator.Directory{{Path: "/var/lib/foo", Permissions: &permissions, Owner: "foo", Group: "bar"}}
+		osc.Symlinks = []*generator.Symlink{{Path: "/usr/local/bin/foo", Target: "/opt/foo/bin/foo"}}
+
+		config, _, err := g.Generate(osc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(config).To(MatchJSON(`{
+  "ignition": {"version": "3.0.0"},
+  "storage": {
+    "directories": [
+      {"path": "/var/lib/foo", "user": {"name": "foo"}, "group": {"name": "bar"}, "mode": 384}
+    ],
+    "files": [
+      {"path": "/etc/foo", "user": {"name": "foo"}, "append": [{"source": "data:;base64,YmFy"}]}
+    ],
+    "links": [
+      {"path": "/usr/local/bin/foo", "overwrite": true, "target": "/opt/foo/bin/foo"}
+    ]
+  },
+  "systemd": {}
+}`))
+	})
+})
@@ -0,0 +1,94 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ignition
+
+// Version is the version of the Ignition config specification the IgnitionGenerator generates.
+const Version = "3.0.0"
+
+// Config is the subset of an Ignition v3 config which is required to represent an OperatingSystemConfig.
+type Config struct {
+	Ignition Ignition `json:"ignition"`
+	Storage  Storage  `json:"storage"`
+	Systemd  Systemd  `json:"systemd"`
+}
+
+// Ignition contains metadata about the config.
+type Ignition struct {
+	Version string `json:"version"`
+}
+
+// Storage describes the desired state of the system's storage devices.
+type Storage struct {
+	Directories []Directory `json:"directories,omitempty"`
+	Files       []File      `json:"files,omitempty"`
+	Links       []Link      `json:"links,omitempty"`
+}
+
+// Node contains the attributes common to files, directories and links.
+type Node struct {
+	Path      string    `json:"path"`
+	Overwrite *bool     `json:"overwrite,omitempty"`
+	User      *NodeUser `json:"user,omitempty"`
+	Group     *NodeUser `json:"group,omitempty"`
+}
+
+// NodeUser is the user or group owning a node.
+type NodeUser struct {
+	Name string `json:"name"`
+}
+
+// Directory is a directory to be created on the system.
+type Directory struct {
+	Node
+	Mode *int `json:"mode,omitempty"`
+}
+
+// File is a file to be written to the system.
+type File struct {
+	Node
+	Mode     *int           `json:"mode,omitempty"`
+	Contents *FileContents  `json:"contents,omitempty"`
+	Append   []FileContents `json:"append,omitempty"`
+}
+
+// Link is a symbolic link to be created on the system.
+type Link struct {
+	Node
+	Target string `json:"target"`
+}
+
+// FileContents are the contents of a file.
+type FileContents struct {
+	Source *string `json:"source,omitempty"`
+}
+
+// Systemd describes the desired state of the systemd units.
+type Systemd struct {
+	Units []Unit `json:"units,omitempty"`
+}
+
+// Unit is a systemd unit.
+type Unit struct {
+	Name     string   `json:"name"`
+	Enabled  *bool    `json:"enabled,omitempty"`
+	Contents *string  `json:"contents,omitempty"`
+	Dropins  []Dropin `json:"dropins,omitempty"`
+}
+
+// Dropin is a drop in of a systemd unit.
+type Dropin struct {
+	Name     string  `json:"name"`
+	Contents *string `json:"contents,omitempty"`
+}
@@ -33,6 +33,22 @@ type fileData struct {
 	Content     string
 	Dirname     string
 	Permissions *string
+	Owner       string
+	Group       string
+	Append      bool
+}
+
+type directoryData struct {
+	Path        string
+	Permissions *string
+	Owner       string
+	Group       string
+}
+
+type symlinkData struct {
+	Path    string
+	Target  string
+	Dirname string
 }
 
 type unitData struct {
@@ -53,10 +69,12 @@ type dropInData struct {
 }
 
 type initScriptData struct {
-	CRI       *extensionsv1alpha1.CRIConfig
-	Files     []*fileData
-	Units     []*unitData
-	Bootstrap bool
+	CRI         *extensionsv1alpha1.CRIConfig
+	Files       []*fileData
+	Directories []*directoryData
+	Symlinks    []*symlinkData
+	Units       []*unitData
+	Bootstrap   bool
 }
 
 // CloudInitGenerator generates cloud-init scripts.
@@ -70,22 +88,49 @@ func b64(data []byte) string {
 	return base64.StdEncoding.EncodeToString(data)
 }
 
+func permissions(p *int32) *string {
+	if p == nil {
+		return nil
+	}
+	permissions := fmt.Sprintf("%04o", *p)
+	return &permissions
+}
+
 // Generate generates a cloud-init script from the given OperatingSystemConfig.
 func (t *CloudInitGenerator) Generate(data *generator.OperatingSystemConfig) ([]byte, *string, error) {
 	var tFiles []*fileData
 	for _, file := range data.Files {
 		tFile := &fileData{
-			Path:    file.Path,
-			Content: b64(file.Content),
-			Dirname: path.Dir(file.Path),
-		}
-		if file.Permissions != nil {
-			permissions := fmt.Sprintf("%04o", *file.Permissions)
-			tFile.Permissions = &permissions
+			Path:        file.Path,
+			Content:     b64(file.Content),
+			Dirname:     path.Dir(file.Path),
+			Permissions: permissions(file.Permissions),
+			Owner:       generator.OwnerOrDefault(file.Owner),
+			Group:       generator.OwnerOrDefault(file.Group),
+			Append:      file.Append,
 		}
 		tFiles = append(tFiles, tFile)
 	}
 
+	var tDirectories []*directoryData
+	for _, directory := range data.Directories {
+		tDirectories = append(tDirectories, &directoryData{
+			Path:        directory.Path,
+			Permissions: permissions(directory.Permissions),
+			Owner:       generator.OwnerOrDefault(directory.Owner),
+			Group:       generator.OwnerOrDefault(directory.Group),
+		})
+	}
+
+	var tSymlinks []*symlinkData
+	for _, symlink := range data.Symlinks {
+		tSymlinks = append(tSymlinks, &symlinkData{
+			Path:    symlink.Path,
+			Target:  symlink.Target,
+			Dirname: path.Dir(symlink.Path),
+		})
+	}
+
 	var tUnits []*unitData
 	for _, unit := range data.Units {
 		var content *string
@@ -119,10 +164,12 @@ func (t *CloudInitGenerator) Generate(data *generator.OperatingSystemConfig) ([]
 
 	var buf bytes.Buffer
 	if err := t.cloudInitTemplate.Execute(&buf, &initScriptData{
-		CRI:       data.CRI,
-		Files:     tFiles,
-		Units:     tUnits,
-		Bootstrap: data.Bootstrap,
+		CRI:         data.CRI,
+		Files:       tFiles,
+		Directories: tDirectories,
+		Symlinks:    tSymlinks,
+		Units:       tUnits,
+		Bootstrap:   data.Bootstrap,
 	}); err != nil {
 		return nil, nil, err
 	}
@@ -0,0 +1,96 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template_test
+
+import (
+	"text/template"
+
+	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/generator"
+	. "github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/template"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const testTemplate = `{{- range .Files }}
+file {{ .Path }} in {{ .Dirname }} {{ .Owner }}:{{ .Group }}{{ if .Permissions }} {{ .Permissions }}{{ end }}{{ if .Append }} append{{ end }} {{ .Content }}
+{{- end }}
+{{- range .Directories }}
+directory {{ .Path }} {{ .Owner }}:{{ .Group }}{{ if .Permissions }} {{ .Permissions }}{{ end }}
+{{- end }}
+{{- range .Symlinks }}
+symlink {{ .Path }} in {{ .Dirname }} -> {{ .Target }}
+{{- end }}
+{{- range .Units }}
+unit {{ .Path }}{{ if .Content }} {{ .Content }}{{ end }}
+{{- if .DropIns }}{{ range .DropIns.Items }}
+drop-in {{ .Path }} {{ .Content }}
+{{- end }}{{ end }}
+{{- end }}
+`
+
+var _ = Describe("CloudInitGenerator", func() {
+	var (
+		g           *CloudInitGenerator
+		permissions = int32(0600)
+		dirPerms    = int32(0750)
+		path        = "/var/lib/osc/config"
+	)
+
+	BeforeEach(func() {
+		g = NewCloudInitGenerator(template.Must(NewTemplate("test").Parse(testTemplate)), DefaultUnitsPath, "/bin/bash %s")
+	})
+
+	It("should pass the file, directory, symlink and unit data to the template", func() {
+		data, cmd, err := g.Generate(&generator.OperatingSystemConfig{
+			Files: []*generator.File{
+				{Path: "/etc/foo/bar", Content: []byte("bar"), Permissions: &permissions, Owner: "foo", Group: "bar", Append: true},
+				{Path: "/baz", Content: []byte("qux")},
+			},
+			Directories: []*generator.Directory{
+				{Path: "/var/lib/foo", Permissions: &dirPerms, Owner: "foo"},
+				{Path: "/var/lib/bar"},
+			},
+			Symlinks: []*generator.Symlink{
+				{Path: "/usr/local/bin/foo", Target: "/opt/foo/bin/foo"},
+			},
+			Units: []*generator.Unit{
+				{
+					Name:    "docker.service",
+					Content: []byte("unit"),
+					DropIns: []*generator.DropIn{{Name: "10-docker-opts.conf", Content: []byte("override")}},
+				},
+			},
+			Path: &path,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*cmd).To(Equal("/bin/bash /var/lib/osc/config"))
+		Expect(string(data)).To(Equal(`
+file /etc/foo/bar in /etc/foo foo:bar 0600 append YmFy
+file /baz in / root:root cXV4
+directory /var/lib/foo foo:root 0750
+directory /var/lib/bar root:root
+symlink /usr/local/bin/foo in /usr/local/bin -> /opt/foo/bin/foo
+unit /etc/systemd/system/docker.service dW5pdA==
+drop-in /etc/systemd/system/docker.service.d/10-docker-opts.conf b3ZlcnJpZGU=
+`))
+	})
+
+	It("should not set a command if no path is given", func() {
+		_, cmd, err := g.Generate(&generator.OperatingSystemConfig{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cmd).To(BeNil())
+	})
+})
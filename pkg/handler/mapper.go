@@ -19,6 +19,7 @@ import (
 
 	extensionspredicate "github.com/gardener/gardener-extensions/pkg/predicate"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -67,6 +68,65 @@ func (m *clusterToObjectMapper) Map(obj handler.MapObject) []reconcile.Request {
 		return nil
 	}
 
+	return requestsForListItems(objList, m.predicates)
+}
+
+// ClusterToObjectMapper returns a mapper that returns requests for objects whose
+// referenced clusters have been modified.
+func ClusterToObjectMapper(newObjListFunc func() runtime.Object, predicates []predicate.Predicate) handler.Mapper {
+	return &clusterToObjectMapper{newObjListFunc: newObjListFunc, predicates: predicates}
+}
+
+type secretToObjectMapper struct {
+	client         client.Client
+	newObjListFunc func() runtime.Object
+	indexField     string
+	predicates     []predicate.Predicate
+}
+
+func (m *secretToObjectMapper) InjectClient(c client.Client) error {
+	m.client = c
+	return nil
+}
+
+func (m *secretToObjectMapper) InjectFunc(f inject.Func) error {
+	for _, p := range m.predicates {
+		if err := f(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *secretToObjectMapper) Map(obj handler.MapObject) []reconcile.Request {
+	ctx := context.TODO()
+
+	if obj.Object == nil {
+		return nil
+	}
+
+	secret, ok := obj.Object.(*corev1.Secret)
+	if !ok {
+		return nil
+	}
+
+	objList := m.newObjListFunc()
+	if err := m.client.List(ctx, objList, client.InNamespace(secret.Namespace), client.MatchingFields{m.indexField: secret.Name}); err != nil {
+		return nil
+	}
+
+	return requestsForListItems(objList, m.predicates)
+}
+
+// SecretToObjectMapper returns a mapper that returns requests for objects whose
+// referenced secrets have been modified. The objects referencing a secret are found
+// by the given field index, which must map the objects to the names of the secrets
+// they reference.
+func SecretToObjectMapper(newObjListFunc func() runtime.Object, indexField string, predicates []predicate.Predicate) handler.Mapper {
+	return &secretToObjectMapper{newObjListFunc: newObjListFunc, indexField: indexField, predicates: predicates}
+}
+
+func requestsForListItems(objList runtime.Object, predicates []predicate.Predicate) []reconcile.Request {
 	var requests []reconcile.Request
 
 	utilruntime.HandleError(meta.EachListItem(objList, func(obj runtime.Object) error {
@@ -75,7 +135,7 @@ func (m *clusterToObjectMapper) Map(obj handler.MapObject) []reconcile.Request {
 			return err
 		}
 
-		if !extensionspredicate.EvalGeneric(obj, m.predicates...) {
+		if !extensionspredicate.EvalGeneric(obj, predicates...) {
 			return nil
 		}
 
@@ -89,9 +149,3 @@ func (m *clusterToObjectMapper) Map(obj handler.MapObject) []reconcile.Request {
 	}))
 	return requests
 }
-
-// ClusterToObjectMapper returns a mapper that returns requests for objects whose
-// referenced clusters have been modified.
-func ClusterToObjectMapper(newObjListFunc func() runtime.Object, predicates []predicate.Predicate) handler.Mapper {
-	return &clusterToObjectMapper{newObjListFunc: newObjListFunc, predicates: predicates}
-}
@@ -24,6 +24,7 @@ import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -183,6 +184,68 @@ var _ = Describe("Controller Mapper", func() {
 			Expect(result).To(BeNil())
 		})
 	})
+
+	Describe("#SecretToObjectMapper", func() {
+		var (
+			resourceName = "osc"
+			namespace    = "shoot"
+			secretName   = "secret"
+			indexField   = "spec.files.content.secretRef.name"
+
+			newObjListFunc = func() runtime.Object { return &extensionsv1alpha1.OperatingSystemConfigList{} }
+		)
+
+		It("should find all objects referencing the passed secret", func() {
+			mapper := SecretToObjectMapper(newObjListFunc, indexField, nil)
+			ExpectInject(inject.ClientInto(c, mapper))
+
+			c.EXPECT().
+				List(
+					gomock.AssignableToTypeOf(context.TODO()),
+					gomock.AssignableToTypeOf(&extensionsv1alpha1.OperatingSystemConfigList{}),
+					client.InNamespace(namespace),
+					client.MatchingFields{indexField: secretName},
+				).
+				DoAndReturn(func(_ context.Context, actual *extensionsv1alpha1.OperatingSystemConfigList, _ ...client.ListOption) error {
+					*actual = extensionsv1alpha1.OperatingSystemConfigList{
+						Items: []extensionsv1alpha1.OperatingSystemConfig{
+							{
+								ObjectMeta: metav1.ObjectMeta{
+									Name:      resourceName,
+									Namespace: namespace,
+								},
+							},
+						},
+					}
+					return nil
+				})
+
+			result := mapper.Map(handler.MapObject{
+				Object: &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      secretName,
+						Namespace: namespace,
+					},
+				},
+			})
+
+			Expect(result).To(ConsistOf(reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      resourceName,
+					Namespace: namespace,
+				},
+			}))
+		})
+
+		It("should find no objects because the passed object is no secret", func() {
+			mapper := SecretToObjectMapper(newObjListFunc, indexField, nil)
+			ExpectInject(inject.ClientInto(c, mapper))
+			result := mapper.Map(handler.MapObject{
+				Object: &extensionsv1alpha1.Cluster{},
+			})
+			Expect(result).To(BeNil())
+		})
+	})
 })
 
 func ExpectInject(ok bool, err error) {
@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/gardener/gardener-extensions/pkg/controller/backupbucket/genericactuator (interfaces: BackupBucketDelegate)
+
+// Package genericactuator is a generated GoMock package.
+package genericactuator
+
+import (
+	context "context"
+	genericactuator "github.com/gardener/gardener-extensions/pkg/controller/backupbucket/genericactuator"
+	v1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockBackupBucketDelegate is a mock of BackupBucketDelegate interface
+type MockBackupBucketDelegate struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackupBucketDelegateMockRecorder
+}
+
+// MockBackupBucketDelegateMockRecorder is the mock recorder for MockBackupBucketDelegate
+type MockBackupBucketDelegateMockRecorder struct {
+	mock *MockBackupBucketDelegate
+}
+
+// NewMockBackupBucketDelegate creates a new mock instance
+func NewMockBackupBucketDelegate(ctrl *gomock.Controller) *MockBackupBucketDelegate {
+	mock := &MockBackupBucketDelegate{ctrl: ctrl}
+	mock.recorder = &MockBackupBucketDelegateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockBackupBucketDelegate) EXPECT() *MockBackupBucketDelegateMockRecorder {
+	return m.recorder
+}
+
+// ApplyLifecyclePolicy mocks base method
+func (m *MockBackupBucketDelegate) ApplyLifecyclePolicy(arg0 context.Context, arg1 *v1alpha1.BackupBucket, arg2 map[string][]byte, arg3 genericactuator.LifecyclePolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyLifecyclePolicy", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyLifecyclePolicy indicates an expected call of ApplyLifecyclePolicy
+func (mr *MockBackupBucketDelegateMockRecorder) ApplyLifecyclePolicy(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyLifecyclePolicy", reflect.TypeOf((*MockBackupBucketDelegate)(nil).ApplyLifecyclePolicy), arg0, arg1, arg2, arg3)
+}
+
+// CreateBucket mocks base method
+func (m *MockBackupBucketDelegate) CreateBucket(arg0 context.Context, arg1 *v1alpha1.BackupBucket, arg2 map[string][]byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBucket", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBucket indicates an expected call of CreateBucket
+func (mr *MockBackupBucketDelegateMockRecorder) CreateBucket(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBucket", reflect.TypeOf((*MockBackupBucketDelegate)(nil).CreateBucket), arg0, arg1, arg2)
+}
+
+// DeleteBucket mocks base method
+func (m *MockBackupBucketDelegate) DeleteBucket(arg0 context.Context, arg1 *v1alpha1.BackupBucket, arg2 map[string][]byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBucket", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBucket indicates an expected call of DeleteBucket
+func (mr *MockBackupBucketDelegateMockRecorder) DeleteBucket(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBucket", reflect.TypeOf((*MockBackupBucketDelegate)(nil).DeleteBucket), arg0, arg1, arg2)
+}
+
+// GenerateCredentials mocks base method
+func (m *MockBackupBucketDelegate) GenerateCredentials(arg0 context.Context, arg1 *v1alpha1.BackupBucket, arg2 map[string][]byte) (map[string][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateCredentials", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[string][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateCredentials indicates an expected call of GenerateCredentials
+func (mr *MockBackupBucketDelegateMockRecorder) GenerateCredentials(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateCredentials", reflect.TypeOf((*MockBackupBucketDelegate)(nil).GenerateCredentials), arg0, arg1, arg2)
+}
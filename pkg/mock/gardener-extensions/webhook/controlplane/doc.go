@@ -12,6 +12,6 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:generate mockgen -package=controlplane -destination=mocks.go github.com/gardener/gardener-extensions/pkg/webhook/controlplane KubeletConfigCodec,UnitSerializer,FileContentInlineCodec
+//go:generate mockgen -package=controlplane -destination=mocks.go github.com/gardener/gardener-extensions/pkg/webhook/controlplane KubeletConfigCodec,ContainerdConfigCodec,UnitSerializer,FileContentInlineCodec
 
 package controlplane
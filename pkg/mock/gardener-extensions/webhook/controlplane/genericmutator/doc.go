@@ -12,6 +12,6 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:generate mockgen -package=genericmutator -destination=mocks.go github.com/gardener/gardener-extensions/pkg/webhook/controlplane/genericmutator Ensurer
+//go:generate mockgen -package=genericmutator -destination=mocks.go github.com/gardener/gardener-extensions/pkg/webhook/controlplane/genericmutator Ensurer,ClusterAutoscalerEnsurer,KubeAPIServerAutoscalingEnsurer,VPNSeedEnsurer,KubeProxyConfigEnsurer,ContainerRuntimeConfigEnsurer,ContainerdConfigEnsurer
 
 package genericmutator
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/gardener/gardener-extensions/pkg/webhook/controlplane/genericmutator (interfaces: Ensurer)
+// Source: github.com/gardener/gardener-extensions/pkg/webhook/controlplane/genericmutator (interfaces: Ensurer,ClusterAutoscalerEnsurer,KubeAPIServerAutoscalingEnsurer,VPNSeedEnsurer,KubeProxyConfigEnsurer,ContainerRuntimeConfigEnsurer,ContainerdConfigEnsurer)
 
 // Package genericmutator is a generated GoMock package.
 package genericmutator
@@ -8,11 +8,14 @@ import (
 	context "context"
 	unit "github.com/coreos/go-systemd/unit"
 	v1alpha1 "github.com/gardener/etcd-druid/api/v1alpha1"
+	controlplane "github.com/gardener/gardener-extensions/pkg/webhook/controlplane"
 	genericmutator "github.com/gardener/gardener-extensions/pkg/webhook/controlplane/genericmutator"
 	v1alpha10 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	gomock "github.com/golang/mock/gomock"
 	v1 "k8s.io/api/apps/v1"
+	v2beta1 "k8s.io/api/autoscaling/v2beta1"
 	v10 "k8s.io/api/core/v1"
+	v1beta2 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1beta2"
 	v1beta1 "k8s.io/kubelet/config/v1beta1"
 	reflect "reflect"
 )
@@ -208,3 +211,253 @@ func (mr *MockEnsurerMockRecorder) ShouldProvisionKubeletCloudProviderConfig() *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShouldProvisionKubeletCloudProviderConfig", reflect.TypeOf((*MockEnsurer)(nil).ShouldProvisionKubeletCloudProviderConfig))
 }
+
+// MockClusterAutoscalerEnsurer is a mock of ClusterAutoscalerEnsurer interface
+type MockClusterAutoscalerEnsurer struct {
+	ctrl     *gomock.Controller
+	recorder *MockClusterAutoscalerEnsurerMockRecorder
+}
+
+// MockClusterAutoscalerEnsurerMockRecorder is the mock recorder for MockClusterAutoscalerEnsurer
+type MockClusterAutoscalerEnsurerMockRecorder struct {
+	mock *MockClusterAutoscalerEnsurer
+}
+
+// NewMockClusterAutoscalerEnsurer creates a new mock instance
+func NewMockClusterAutoscalerEnsurer(ctrl *gomock.Controller) *MockClusterAutoscalerEnsurer {
+	mock := &MockClusterAutoscalerEnsurer{ctrl: ctrl}
+	mock.recorder = &MockClusterAutoscalerEnsurerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockClusterAutoscalerEnsurer) EXPECT() *MockClusterAutoscalerEnsurerMockRecorder {
+	return m.recorder
+}
+
+// EnsureClusterAutoscalerDeployment mocks base method
+func (m *MockClusterAutoscalerEnsurer) EnsureClusterAutoscalerDeployment(arg0 context.Context, arg1 genericmutator.EnsurerContext, arg2, arg3 *v1.Deployment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureClusterAutoscalerDeployment", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureClusterAutoscalerDeployment indicates an expected call of EnsureClusterAutoscalerDeployment
+func (mr *MockClusterAutoscalerEnsurerMockRecorder) EnsureClusterAutoscalerDeployment(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureClusterAutoscalerDeployment", reflect.TypeOf((*MockClusterAutoscalerEnsurer)(nil).EnsureClusterAutoscalerDeployment), arg0, arg1, arg2, arg3)
+}
+
+// MockKubeAPIServerAutoscalingEnsurer is a mock of KubeAPIServerAutoscalingEnsurer interface
+type MockKubeAPIServerAutoscalingEnsurer struct {
+	ctrl     *gomock.Controller
+	recorder *MockKubeAPIServerAutoscalingEnsurerMockRecorder
+}
+
+// MockKubeAPIServerAutoscalingEnsurerMockRecorder is the mock recorder for MockKubeAPIServerAutoscalingEnsurer
+type MockKubeAPIServerAutoscalingEnsurerMockRecorder struct {
+	mock *MockKubeAPIServerAutoscalingEnsurer
+}
+
+// NewMockKubeAPIServerAutoscalingEnsurer creates a new mock instance
+func NewMockKubeAPIServerAutoscalingEnsurer(ctrl *gomock.Controller) *MockKubeAPIServerAutoscalingEnsurer {
+	mock := &MockKubeAPIServerAutoscalingEnsurer{ctrl: ctrl}
+	mock.recorder = &MockKubeAPIServerAutoscalingEnsurerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockKubeAPIServerAutoscalingEnsurer) EXPECT() *MockKubeAPIServerAutoscalingEnsurerMockRecorder {
+	return m.recorder
+}
+
+// EnsureKubeAPIServerHPA mocks base method
+func (m *MockKubeAPIServerAutoscalingEnsurer) EnsureKubeAPIServerHPA(arg0 context.Context, arg1 genericmutator.EnsurerContext, arg2, arg3 *v2beta1.HorizontalPodAutoscaler) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureKubeAPIServerHPA", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureKubeAPIServerHPA indicates an expected call of EnsureKubeAPIServerHPA
+func (mr *MockKubeAPIServerAutoscalingEnsurerMockRecorder) EnsureKubeAPIServerHPA(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureKubeAPIServerHPA", reflect.TypeOf((*MockKubeAPIServerAutoscalingEnsurer)(nil).EnsureKubeAPIServerHPA), arg0, arg1, arg2, arg3)
+}
+
+// EnsureKubeAPIServerVPA mocks base method
+func (m *MockKubeAPIServerAutoscalingEnsurer) EnsureKubeAPIServerVPA(arg0 context.Context, arg1 genericmutator.EnsurerContext, arg2, arg3 *v1beta2.VerticalPodAutoscaler) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureKubeAPIServerVPA", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureKubeAPIServerVPA indicates an expected call of EnsureKubeAPIServerVPA
+func (mr *MockKubeAPIServerAutoscalingEnsurerMockRecorder) EnsureKubeAPIServerVPA(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureKubeAPIServerVPA", reflect.TypeOf((*MockKubeAPIServerAutoscalingEnsurer)(nil).EnsureKubeAPIServerVPA), arg0, arg1, arg2, arg3)
+}
+
+// MockVPNSeedEnsurer is a mock of VPNSeedEnsurer interface
+type MockVPNSeedEnsurer struct {
+	ctrl     *gomock.Controller
+	recorder *MockVPNSeedEnsurerMockRecorder
+}
+
+// MockVPNSeedEnsurerMockRecorder is the mock recorder for MockVPNSeedEnsurer
+type MockVPNSeedEnsurerMockRecorder struct {
+	mock *MockVPNSeedEnsurer
+}
+
+// NewMockVPNSeedEnsurer creates a new mock instance
+func NewMockVPNSeedEnsurer(ctrl *gomock.Controller) *MockVPNSeedEnsurer {
+	mock := &MockVPNSeedEnsurer{ctrl: ctrl}
+	mock.recorder = &MockVPNSeedEnsurerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockVPNSeedEnsurer) EXPECT() *MockVPNSeedEnsurerMockRecorder {
+	return m.recorder
+}
+
+// EnsureVPNSeedContainer mocks base method
+func (m *MockVPNSeedEnsurer) EnsureVPNSeedContainer(arg0 context.Context, arg1 genericmutator.EnsurerContext, arg2, arg3 *v10.Container) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureVPNSeedContainer", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureVPNSeedContainer indicates an expected call of EnsureVPNSeedContainer
+func (mr *MockVPNSeedEnsurerMockRecorder) EnsureVPNSeedContainer(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureVPNSeedContainer", reflect.TypeOf((*MockVPNSeedEnsurer)(nil).EnsureVPNSeedContainer), arg0, arg1, arg2, arg3)
+}
+
+// MockKubeProxyConfigEnsurer is a mock of KubeProxyConfigEnsurer interface
+type MockKubeProxyConfigEnsurer struct {
+	ctrl     *gomock.Controller
+	recorder *MockKubeProxyConfigEnsurerMockRecorder
+}
+
+// MockKubeProxyConfigEnsurerMockRecorder is the mock recorder for MockKubeProxyConfigEnsurer
+type MockKubeProxyConfigEnsurerMockRecorder struct {
+	mock *MockKubeProxyConfigEnsurer
+}
+
+// NewMockKubeProxyConfigEnsurer creates a new mock instance
+func NewMockKubeProxyConfigEnsurer(ctrl *gomock.Controller) *MockKubeProxyConfigEnsurer {
+	mock := &MockKubeProxyConfigEnsurer{ctrl: ctrl}
+	mock.recorder = &MockKubeProxyConfigEnsurerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockKubeProxyConfigEnsurer) EXPECT() *MockKubeProxyConfigEnsurerMockRecorder {
+	return m.recorder
+}
+
+// EnsureKubeProxyConfiguration mocks base method
+func (m *MockKubeProxyConfigEnsurer) EnsureKubeProxyConfiguration(arg0 context.Context, arg1 genericmutator.EnsurerContext, arg2, arg3 *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureKubeProxyConfiguration", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureKubeProxyConfiguration indicates an expected call of EnsureKubeProxyConfiguration
+func (mr *MockKubeProxyConfigEnsurerMockRecorder) EnsureKubeProxyConfiguration(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureKubeProxyConfiguration", reflect.TypeOf((*MockKubeProxyConfigEnsurer)(nil).EnsureKubeProxyConfiguration), arg0, arg1, arg2, arg3)
+}
+
+// MockContainerRuntimeConfigEnsurer is a mock of ContainerRuntimeConfigEnsurer interface
+type MockContainerRuntimeConfigEnsurer struct {
+	ctrl     *gomock.Controller
+	recorder *MockContainerRuntimeConfigEnsurerMockRecorder
+}
+
+// MockContainerRuntimeConfigEnsurerMockRecorder is the mock recorder for MockContainerRuntimeConfigEnsurer
+type MockContainerRuntimeConfigEnsurerMockRecorder struct {
+	mock *MockContainerRuntimeConfigEnsurer
+}
+
+// NewMockContainerRuntimeConfigEnsurer creates a new mock instance
+func NewMockContainerRuntimeConfigEnsurer(ctrl *gomock.Controller) *MockContainerRuntimeConfigEnsurer {
+	mock := &MockContainerRuntimeConfigEnsurer{ctrl: ctrl}
+	mock.recorder = &MockContainerRuntimeConfigEnsurerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockContainerRuntimeConfigEnsurer) EXPECT() *MockContainerRuntimeConfigEnsurerMockRecorder {
+	return m.recorder
+}
+
+// EnsureContainerdConfigFile mocks base method
+func (m *MockContainerRuntimeConfigEnsurer) EnsureContainerdConfigFile(arg0 context.Context, arg1 genericmutator.EnsurerContext, arg2, arg3 *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureContainerdConfigFile", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureContainerdConfigFile indicates an expected call of EnsureContainerdConfigFile
+func (mr *MockContainerRuntimeConfigEnsurerMockRecorder) EnsureContainerdConfigFile(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureContainerdConfigFile", reflect.TypeOf((*MockContainerRuntimeConfigEnsurer)(nil).EnsureContainerdConfigFile), arg0, arg1, arg2, arg3)
+}
+
+// EnsureDockerDaemonConfigFile mocks base method
+func (m *MockContainerRuntimeConfigEnsurer) EnsureDockerDaemonConfigFile(arg0 context.Context, arg1 genericmutator.EnsurerContext, arg2, arg3 *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureDockerDaemonConfigFile", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureDockerDaemonConfigFile indicates an expected call of EnsureDockerDaemonConfigFile
+func (mr *MockContainerRuntimeConfigEnsurerMockRecorder) EnsureDockerDaemonConfigFile(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureDockerDaemonConfigFile", reflect.TypeOf((*MockContainerRuntimeConfigEnsurer)(nil).EnsureDockerDaemonConfigFile), arg0, arg1, arg2, arg3)
+}
+
+// MockContainerdConfigEnsurer is a mock of ContainerdConfigEnsurer interface
+type MockContainerdConfigEnsurer struct {
+	ctrl     *gomock.Controller
+	recorder *MockContainerdConfigEnsurerMockRecorder
+}
+
+// MockContainerdConfigEnsurerMockRecorder is the mock recorder for MockContainerdConfigEnsurer
+type MockContainerdConfigEnsurerMockRecorder struct {
+	mock *MockContainerdConfigEnsurer
+}
+
+// NewMockContainerdConfigEnsurer creates a new mock instance
+func NewMockContainerdConfigEnsurer(ctrl *gomock.Controller) *MockContainerdConfigEnsurer {
+	mock := &MockContainerdConfigEnsurer{ctrl: ctrl}
+	mock.recorder = &MockContainerdConfigEnsurerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockContainerdConfigEnsurer) EXPECT() *MockContainerdConfigEnsurerMockRecorder {
+	return m.recorder
+}
+
+// EnsureContainerdConfig mocks base method
+func (m *MockContainerdConfigEnsurer) EnsureContainerdConfig(arg0 context.Context, arg1 genericmutator.EnsurerContext, arg2, arg3 *controlplane.ContainerdConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureContainerdConfig", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureContainerdConfig indicates an expected call of EnsureContainerdConfig
+func (mr *MockContainerdConfigEnsurerMockRecorder) EnsureContainerdConfig(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureContainerdConfig", reflect.TypeOf((*MockContainerdConfigEnsurer)(nil).EnsureContainerdConfig), arg0, arg1, arg2, arg3)
+}
@@ -1,11 +1,12 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/gardener/gardener-extensions/pkg/webhook/controlplane (interfaces: KubeletConfigCodec,UnitSerializer,FileContentInlineCodec)
+// Source: github.com/gardener/gardener-extensions/pkg/webhook/controlplane (interfaces: KubeletConfigCodec,ContainerdConfigCodec,UnitSerializer,FileContentInlineCodec)
 
 // Package controlplane is a generated GoMock package.
 package controlplane
 
 import (
 	unit "github.com/coreos/go-systemd/unit"
+	controlplane "github.com/gardener/gardener-extensions/pkg/webhook/controlplane"
 	v1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	gomock "github.com/golang/mock/gomock"
 	v1beta1 "k8s.io/kubelet/config/v1beta1"
@@ -65,6 +66,59 @@ func (mr *MockKubeletConfigCodecMockRecorder) Encode(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Encode", reflect.TypeOf((*MockKubeletConfigCodec)(nil).Encode), arg0, arg1)
 }
 
+// MockContainerdConfigCodec is a mock of ContainerdConfigCodec interface
+type MockContainerdConfigCodec struct {
+	ctrl     *gomock.Controller
+	recorder *MockContainerdConfigCodecMockRecorder
+}
+
+// MockContainerdConfigCodecMockRecorder is the mock recorder for MockContainerdConfigCodec
+type MockContainerdConfigCodecMockRecorder struct {
+	mock *MockContainerdConfigCodec
+}
+
+// NewMockContainerdConfigCodec creates a new mock instance
+func NewMockContainerdConfigCodec(ctrl *gomock.Controller) *MockContainerdConfigCodec {
+	mock := &MockContainerdConfigCodec{ctrl: ctrl}
+	mock.recorder = &MockContainerdConfigCodecMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockContainerdConfigCodec) EXPECT() *MockContainerdConfigCodecMockRecorder {
+	return m.recorder
+}
+
+// Decode mocks base method
+func (m *MockContainerdConfigCodec) Decode(arg0 *v1alpha1.FileContentInline) (*controlplane.ContainerdConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decode", arg0)
+	ret0, _ := ret[0].(*controlplane.ContainerdConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decode indicates an expected call of Decode
+func (mr *MockContainerdConfigCodecMockRecorder) Decode(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decode", reflect.TypeOf((*MockContainerdConfigCodec)(nil).Decode), arg0)
+}
+
+// Encode mocks base method
+func (m *MockContainerdConfigCodec) Encode(arg0 *controlplane.ContainerdConfig, arg1 string) (*v1alpha1.FileContentInline, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Encode", arg0, arg1)
+	ret0, _ := ret[0].(*v1alpha1.FileContentInline)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Encode indicates an expected call of Encode
+func (mr *MockContainerdConfigCodecMockRecorder) Encode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Encode", reflect.TypeOf((*MockContainerdConfigCodec)(nil).Encode), arg0, arg1)
+}
+
 // MockUnitSerializer is a mock of UnitSerializer interface
 type MockUnitSerializer struct {
 	ctrl     *gomock.Controller
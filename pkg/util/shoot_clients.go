@@ -70,6 +70,16 @@ func NewShootClients(c client.Client, clientset kubernetes.Interface, gardenerCl
 
 // NewClientForShoot returns the rest config and the client for the given shoot namespace.
 func NewClientForShoot(ctx context.Context, c client.Client, namespace string, opts client.Options) (*rest.Config, client.Client, error) {
+	gardenerSecret, err := GetKubeconfigSecretForShoot(ctx, c, namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewClientForShootFromSecret(gardenerSecret, opts)
+}
+
+// GetKubeconfigSecretForShoot returns the secret containing the kubeconfig for the shoot cluster in the given shoot
+// namespace. It prefers the 'gardener-internal' secret and falls back to the 'gardener' secret.
+func GetKubeconfigSecretForShoot(ctx context.Context, c client.Client, namespace string) (*corev1.Secret, error) {
 	var (
 		gardenerSecret = &corev1.Secret{}
 		err            error
@@ -79,10 +89,15 @@ func NewClientForShoot(ctx context.Context, c client.Client, namespace string, o
 		err = c.Get(ctx, kutil.Key(namespace, v1beta1constants.SecretNameGardener), gardenerSecret)
 	}
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
+	return gardenerSecret, nil
+}
 
-	shootRESTConfig, err := NewRESTConfigFromKubeconfig(gardenerSecret.Data[secrets.DataKeyKubeconfig])
+// NewClientForShootFromSecret returns the rest config and the client for the shoot cluster using the kubeconfig
+// contained in the given secret.
+func NewClientForShootFromSecret(secret *corev1.Secret, opts client.Options) (*rest.Config, client.Client, error) {
+	shootRESTConfig, err := NewRESTConfigFromKubeconfig(secret.Data[secrets.DataKeyKubeconfig])
 	if err != nil {
 		return nil, nil, err
 	}
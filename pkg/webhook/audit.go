@@ -0,0 +1,116 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// AnnotationMutationPatchHashPrefix is the prefix of the annotation that contains the SHA256 hash of the JSON patch
+	// of the last mutation applied by a webhook. The name of the webhook is appended to the prefix. The full patch is
+	// not stored in the annotation to stay within the size limit of annotations, but it is logged together with its hash.
+	AnnotationMutationPatchHashPrefix = "mutation.webhook.extensions.gardener.cloud/"
+
+	// EventReasonMutated is the reason of the events that are recorded for mutations applied by webhooks.
+	EventReasonMutated = "Mutated"
+)
+
+// AuditOptions are options for recording the mutations applied by webhooks. Every mutation is always logged and
+// counted in the webhook metrics.
+type AuditOptions struct {
+	// Annotate enables recording the hash of the JSON patch of the last mutation of a webhook in an annotation of the
+	// mutated object.
+	Annotate bool
+	// Recorder is used to record an event containing the JSON patch on the mutated object, if set. Events are only
+	// recorded for seed webhooks, since the recorder creates them in the seed, and only for objects that already have
+	// a UID, i.e. not for objects that are being created.
+	Recorder record.EventRecorder
+}
+
+// auditInjector is implemented by handlers that record the mutations they apply.
+type auditInjector interface {
+	injectAudit(webhook string, opts AuditOptions)
+}
+
+// InjectAudit configures the handler of the given webhook to record the mutations it applies under the name of the
+// webhook, using the given options. Event recording is disabled for shoot webhooks.
+func InjectAudit(wh *Webhook, opts AuditOptions) {
+	if wh.Target == TargetShoot {
+		opts.Recorder = nil
+	}
+
+	var h interface{} = wh.Handler
+	if wh.Webhook != nil {
+		h = wh.Webhook.Handler
+	}
+
+	if i, ok := h.(auditInjector); ok {
+		i.injectAudit(wh.Name, opts)
+	}
+}
+
+type auditor struct {
+	webhook string
+	opts    AuditOptions
+}
+
+// record records the mutation that is described by the given patch response. If annotating is enabled, the patch is
+// hash is added as annotation to the given new object, and a new patch response containing the annotation is returned.
+func (a *auditor) record(resp admission.Response, kind string, oldObjMarshaled []byte, newObj runtime.Object, accessor metav1.Object, logger logr.Logger) admission.Response {
+	patch, err := json.Marshal(resp.Patches)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	patchHash := sha256.Sum256(patch)
+	hash := hex.EncodeToString(patchHash[:])
+
+	mutationsTotal.WithLabelValues(a.webhook, kind).Inc()
+	mutationPatchSize.WithLabelValues(a.webhook).Observe(float64(len(patch)))
+	logger.Info("Mutated resource", "webhook", a.webhook, "kind", kind, "namespace", accessor.GetNamespace(), "name", accessor.GetName(), "patch", string(patch), "patchHash", hash)
+
+	// Events can't be correlated with objects that don't exist yet
+	if a.opts.Recorder != nil && len(accessor.GetUID()) > 0 {
+		a.opts.Recorder.Eventf(newObj, corev1.EventTypeNormal, EventReasonMutated, "Webhook %q applied patch %s", a.webhook, patch)
+	}
+
+	if !a.opts.Annotate || len(a.webhook) == 0 {
+		return resp
+	}
+
+	annotations := accessor.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[AnnotationMutationPatchHashPrefix+a.webhook] = hash
+	accessor.SetAnnotations(annotations)
+
+	newObjMarshaled, err := json.Marshal(newObj)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(oldObjMarshaled, newObjMarshaled)
+}
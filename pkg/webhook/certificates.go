@@ -22,6 +22,7 @@ import (
 	"os"
 	"path/filepath"
 
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
 	"github.com/gardener/gardener/pkg/utils/secrets"
 	"github.com/pkg/errors"
@@ -63,7 +64,10 @@ func GenerateCertificates(ctx context.Context, mgr manager.Manager, certDir, nam
 		if err != nil {
 			return nil, errors.Wrapf(err, "error generating new certificates for webhook server")
 		}
-		return writeCertificates(certDir, caCert, serverCert)
+		if err := writeCertificates(certDir, serverCert); err != nil {
+			return nil, err
+		}
+		return caCert.CertificatePEM, nil
 	}
 
 	// The controller stores the generated webhook certificate in a secret in the cluster. It tries to read it. If it does not exist a
@@ -97,28 +101,47 @@ func GenerateCertificates(ctx context.Context, mgr manager.Manager, certDir, nam
 			return nil, err
 		}
 
-		return writeCertificates(certDir, caCert, serverCert)
+		if err := writeCertificates(certDir, serverCert); err != nil {
+			return nil, err
+		}
+		return caCert.CertificatePEM, nil
 	}
 
 	// The secret has been found and we are now trying to read the stored certificate inside it.
-	caCert, serverCert, err = loadExistingCAAndServerCert(secret.Data)
+	_, serverCert, err = loadExistingCAAndServerCert(secret.Data)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error reading data of secret %s/%s", namespace, certSecretName)
 	}
-	return writeCertificates(certDir, caCert, serverCert)
+	if err := writeCertificates(certDir, serverCert); err != nil {
+		return nil, err
+	}
+	return caBundleFromSecretData(secret.Data), nil
 }
 
 func generateNewCAAndServerCert(mode, namespace, name, url string) (*secrets.Certificate, *secrets.Certificate, error) {
-	caConfig := &secrets.CertificateSecretConfig{
-		CommonName: "webhook-ca",
-		CertType:   secrets.CACert,
+	caCert, err := generateCA()
+	if err != nil {
+		return nil, nil, err
 	}
 
-	caCert, err := caConfig.GenerateCertificate()
+	serverCert, err := generateServerCert(mode, namespace, name, url, caCert)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	return caCert, serverCert, nil
+}
+
+func generateCA() (*secrets.Certificate, error) {
+	caConfig := &secrets.CertificateSecretConfig{
+		CommonName: "webhook-ca",
+		CertType:   secrets.CACert,
+	}
+
+	return caConfig.GenerateCertificate()
+}
+
+func generateServerCert(mode, namespace, name, url string, caCert *secrets.Certificate) (*secrets.Certificate, error) {
 	var (
 		dnsNames    []string
 		ipAddresses []net.IP
@@ -152,12 +175,7 @@ func generateNewCAAndServerCert(mode, namespace, name, url string) (*secrets.Cer
 		SigningCA:   caCert,
 	}
 
-	serverCert, err := serverConfig.GenerateCertificate()
-	if err != nil {
-		return nil, nil, err
-	}
-
-	return caCert, serverCert, nil
+	return serverConfig.GenerateCertificate()
 }
 
 func loadExistingCAAndServerCert(data map[string][]byte) (*secrets.Certificate, *secrets.Certificate, error) {
@@ -190,23 +208,31 @@ func loadExistingCAAndServerCert(data map[string][]byte) (*secrets.Certificate,
 	return caCert, serverCert, nil
 }
 
-func writeCertificates(certDir string, caCert, serverCert *secrets.Certificate) ([]byte, error) {
+// caBundleFromSecretData returns the concatenation of all CA certificates contained in the given secret data that
+// shall currently be trusted by the kube-apiservers calling the webhooks.
+func caBundleFromSecretData(data map[string][]byte) []byte {
+	var caBundle []byte
+	for _, key := range []string{secrets.DataKeyCertificateCA, dataKeyCertificateCANext, dataKeyCertificateCAPrevious} {
+		if cert, ok := data[key]; ok {
+			caBundle = append(caBundle, cert...)
+		}
+	}
+	return caBundle
+}
+
+func writeCertificates(certDir string, serverCert *secrets.Certificate) error {
 	var (
 		serverKeyPath  = filepath.Join(certDir, secrets.DataKeyPrivateKey)
 		serverCertPath = filepath.Join(certDir, secrets.DataKeyCertificate)
 	)
 
 	if err := os.MkdirAll(certDir, 0755); err != nil {
-		return nil, err
+		return err
 	}
 	if err := ioutil.WriteFile(serverKeyPath, serverCert.PrivateKeyPEM, 0666); err != nil {
-		return nil, err
+		return err
 	}
-	if err := ioutil.WriteFile(serverCertPath, serverCert.CertificatePEM, 0666); err != nil {
-		return nil, err
-	}
-
-	return caCert.CertificatePEM, nil
+	return ioutil.WriteFile(serverCertPath, serverCert.CertificatePEM, 0666)
 }
 
 func getClient(mgr manager.Manager) (client.Client, error) {
@@ -214,6 +240,9 @@ func getClient(mgr manager.Manager) (client.Client, error) {
 	if err := scheme.AddToScheme(s); err != nil {
 		return nil, err
 	}
+	if err := extensionsv1alpha1.AddToScheme(s); err != nil {
+		return nil, err
+	}
 
 	return client.New(mgr.GetConfig(), client.Options{Scheme: s})
 }
@@ -0,0 +1,388 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+	"github.com/gardener/gardener/pkg/utils/secrets"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	dataKeyCertificateCANext     = "ca-next.crt"
+	dataKeyPrivateKeyCANext      = "ca-next.key"
+	dataKeyCertificateCAPrevious = "ca-previous.crt"
+
+	// AnnotationCANextAdded is the annotation on the webhook certificate secret that contains the time at which the next
+	// CA has been added to the CA bundle.
+	AnnotationCANextAdded = "webhook.extensions.gardener.cloud/ca-next-added"
+	// AnnotationCARotated is the annotation on the webhook certificate secret that contains the time at which the next
+	// CA has replaced the previous CA for signing the server certificate.
+	AnnotationCARotated = "webhook.extensions.gardener.cloud/ca-rotated"
+
+	// ShootWebhooksResourceName is the name of the managed resource and its secret containing the shoot webhook configs,
+	// which are deployed into the shoot namespaces by the control plane actuator.
+	ShootWebhooksResourceName = "extension-controlplane-shoot-webhooks"
+)
+
+// CertificateRotationOptions are options for the rotation of the webhook certificates.
+type CertificateRotationOptions struct {
+	// CheckInterval is the interval in which the expiration of the certificates is checked.
+	CheckInterval time.Duration
+	// ServerCertRenewBefore is the duration before the expiration of the server certificate at which it is renewed.
+	ServerCertRenewBefore time.Duration
+	// CARenewBefore is the duration before the expiration of the CA certificate at which a new CA is generated.
+	CARenewBefore time.Duration
+	// CATrustPeriod is the duration for which both the old and the new CA are contained in the CA bundle. It must be
+	// long enough so that all webhook configurations are updated with the new CA bundle, and it must be smaller than
+	// CARenewBefore.
+	CATrustPeriod time.Duration
+}
+
+// DefaultCertificateRotationOptions are the default options for the rotation of the webhook certificates.
+var DefaultCertificateRotationOptions = CertificateRotationOptions{
+	CheckInterval:         time.Hour,
+	ServerCertRenewBefore: 30 * 24 * time.Hour,
+	CARenewBefore:         90 * 24 * time.Hour,
+	CATrustPeriod:         14 * 24 * time.Hour,
+}
+
+// NewCertificateRotator creates a new CertificateRotator. The given seed and shoot webhook configs are updated with the
+// new CA bundle whenever it changes. The reconciliation of the shoot webhook configs deployed into the shoots is
+// triggered by the ShootWebhookReconcileTrigger.
+func NewCertificateRotator(mgr manager.Manager, certDir, namespace, name, mode, url string, caBundle []byte, seedWebhooks, shootWebhooks *Configs, opts CertificateRotationOptions, logger logr.Logger) *CertificateRotator {
+	return &CertificateRotator{
+		mgr:           mgr,
+		certDir:       certDir,
+		namespace:     namespace,
+		name:          name,
+		mode:          mode,
+		url:           url,
+		caBundle:      caBundle,
+		seedWebhooks:  seedWebhooks,
+		shootWebhooks: shootWebhooks,
+		opts:          opts,
+		logger:        logger.WithName("certificate-rotator"),
+	}
+}
+
+// CertificateRotator periodically checks the expiration of the certificates stored in the webhook certificate secret
+// and renews them if necessary. Renewed server certificates are written to the certificate directory of the webhook
+// server which reloads them automatically. CAs are rotated with a trust period during which both the old and the new
+// CA are contained in the CA bundle that is injected into the seed and shoot webhook configs.
+// The rotation is only done if the webhook certificate secret is used, i.e., if a namespace is given.
+type CertificateRotator struct {
+	mgr           manager.Manager
+	certDir       string
+	namespace     string
+	name          string
+	mode          string
+	url           string
+	caBundle      []byte
+	seedWebhooks  *Configs
+	shootWebhooks *Configs
+	opts          CertificateRotationOptions
+	logger        logr.Logger
+
+	client client.Client
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. The certificate rotator must run in every replica as
+// each of them has to write the server certificate to its local certificate directory.
+func (r *CertificateRotator) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements manager.Runnable.
+func (r *CertificateRotator) Start(stopCh <-chan struct{}) error {
+	if len(r.namespace) == 0 {
+		r.logger.Info("Webhook certificate rotation is disabled as no namespace is configured")
+		return nil
+	}
+
+	c, err := getClient(r.mgr)
+	if err != nil {
+		return err
+	}
+	r.client = c
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stopCh
+		cancel()
+	}()
+
+	wait.Until(func() {
+		if err := r.reconcile(ctx); err != nil {
+			r.logger.Error(err, "Could not rotate webhook certificates")
+		}
+	}, r.opts.CheckInterval, stopCh)
+	return nil
+}
+
+func (r *CertificateRotator) reconcile(ctx context.Context) error {
+	secret := &corev1.Secret{}
+	if err := r.client.Get(ctx, kutil.Key(r.namespace, certSecretName), secret); err != nil {
+		return errors.Wrapf(err, "error getting cert secret")
+	}
+
+	changed, err := rotateCertificates(secret, time.Now(), r.opts, r.mode, r.namespace, r.name, r.url)
+	if err != nil {
+		return err
+	}
+	if changed {
+		r.logger.Info("Rotating webhook certificates", "secret", certSecretName)
+		// The update fails with a conflict if another replica has rotated the certificates in the meantime. The result is
+		// picked up with the next check in this case.
+		if err := r.client.Update(ctx, secret); err != nil {
+			return errors.Wrapf(err, "error updating cert secret")
+		}
+	}
+
+	_, serverCert, err := loadExistingCAAndServerCert(secret.Data)
+	if err != nil {
+		return errors.Wrapf(err, "error reading data of secret %s/%s", r.namespace, certSecretName)
+	}
+	if existing, err := ioutil.ReadFile(filepath.Join(r.certDir, secrets.DataKeyCertificate)); err != nil || !bytes.Equal(existing, serverCert.CertificatePEM) {
+		r.logger.Info("Writing renewed server certificate", "certDir", r.certDir)
+		if err := writeCertificates(r.certDir, serverCert); err != nil {
+			return err
+		}
+	}
+
+	if caBundle := caBundleFromSecretData(secret.Data); !bytes.Equal(caBundle, r.caBundle) {
+		r.logger.Info("Updating CA bundle of webhook configurations")
+		r.seedWebhooks.InjectCABundle(caBundle)
+		r.shootWebhooks.InjectCABundle(caBundle)
+
+		if !r.seedWebhooks.IsEmpty() {
+			if err := ReconcileSeedWebhookConfigs(ctx, r.client, r.name, r.seedWebhooks); err != nil {
+				return errors.Wrapf(err, "error updating seed webhook configurations")
+			}
+		}
+		r.caBundle = caBundle
+	}
+
+	return nil
+}
+
+// rotateCertificates rotates the certificates in the given webhook certificate secret if necessary. It returns true if
+// the secret has been changed.
+//
+// The CA is rotated in three steps: If the CA is about to expire, a next CA is generated and added to the CA bundle.
+// After the trust period has passed, the next CA replaces the current one and the server certificate is renewed, while
+// the previous CA is still kept in the CA bundle. After another trust period has passed, the previous CA is removed.
+func rotateCertificates(secret *corev1.Secret, now time.Time, opts CertificateRotationOptions, mode, namespace, name, url string) (bool, error) {
+	caCert, serverCert, err := loadExistingCAAndServerCert(secret.Data)
+	if err != nil {
+		return false, err
+	}
+
+	var (
+		changed           bool
+		renewServerCert   = serverCert.Certificate.NotAfter.Sub(now) < opts.ServerCertRenewBefore
+		trustPeriodPassed = func(annotation string) bool {
+			t, err := time.Parse(time.RFC3339, secret.Annotations[annotation])
+			return err != nil || now.Sub(t) >= opts.CATrustPeriod
+		}
+	)
+
+	// Remove the previous CA from the CA bundle after the trust period.
+	if _, ok := secret.Data[dataKeyCertificateCAPrevious]; ok && trustPeriodPassed(AnnotationCARotated) {
+		delete(secret.Data, dataKeyCertificateCAPrevious)
+		delete(secret.Annotations, AnnotationCARotated)
+		changed = true
+	}
+
+	if _, ok := secret.Data[dataKeyCertificateCANext]; ok {
+		// Replace the current CA with the next CA after the trust period.
+		if trustPeriodPassed(AnnotationCANextAdded) {
+			nextCACert, err := secrets.LoadCertificate("", secret.Data[dataKeyPrivateKeyCANext], secret.Data[dataKeyCertificateCANext])
+			if err != nil {
+				return false, errors.Wrapf(err, "could not load next ca certificate")
+			}
+
+			secret.Data[dataKeyCertificateCAPrevious] = caCert.CertificatePEM
+			secret.Data[secrets.DataKeyCertificateCA] = nextCACert.CertificatePEM
+			secret.Data[secrets.DataKeyPrivateKeyCA] = nextCACert.PrivateKeyPEM
+			delete(secret.Data, dataKeyCertificateCANext)
+			delete(secret.Data, dataKeyPrivateKeyCANext)
+			delete(secret.Annotations, AnnotationCANextAdded)
+			kutil.SetMetaDataAnnotation(secret, AnnotationCARotated, now.UTC().Format(time.RFC3339))
+
+			caCert = nextCACert
+			renewServerCert = true
+			changed = true
+		}
+	} else if caCert.Certificate.NotAfter.Sub(now) < opts.CARenewBefore {
+		// Add a next CA to the CA bundle if the current one is about to expire.
+		nextCACert, err := generateCA()
+		if err != nil {
+			return false, errors.Wrapf(err, "error generating next ca certificate")
+		}
+
+		secret.Data[dataKeyCertificateCANext] = nextCACert.CertificatePEM
+		secret.Data[dataKeyPrivateKeyCANext] = nextCACert.PrivateKeyPEM
+		kutil.SetMetaDataAnnotation(secret, AnnotationCANextAdded, now.UTC().Format(time.RFC3339))
+		changed = true
+	}
+
+	if renewServerCert {
+		serverCert, err := generateServerCert(mode, namespace, name, url, caCert)
+		if err != nil {
+			return false, errors.Wrapf(err, "error generating server certificate")
+		}
+
+		secret.Data[secrets.DataKeyCertificate] = serverCert.CertificatePEM
+		secret.Data[secrets.DataKeyPrivateKey] = serverCert.PrivateKeyPEM
+		changed = true
+	}
+
+	return changed, nil
+}
+
+// NewShootWebhookReconcileTrigger creates a new ShootWebhookReconcileTrigger for the ControlPlanes of the given
+// provider type.
+func NewShootWebhookReconcileTrigger(mgr manager.Manager, namespace, providerType string, caBundle []byte, shootWebhooks *Configs, checkInterval time.Duration, logger logr.Logger) *ShootWebhookReconcileTrigger {
+	return &ShootWebhookReconcileTrigger{
+		mgr:           mgr,
+		namespace:     namespace,
+		providerType:  providerType,
+		caBundle:      caBundle,
+		shootWebhooks: shootWebhooks,
+		checkInterval: checkInterval,
+		logger:        logger.WithName("shoot-webhook-reconcile-trigger"),
+	}
+}
+
+// ShootWebhookReconcileTrigger periodically checks whether the CA bundle in the webhook certificate secret has been
+// rotated. If so, it injects the new CA bundle into the shoot webhook configs and triggers the reconciliation of all
+// ControlPlanes of the provider type whose shoot webhook configs have been deployed by the control plane actuator, so
+// that the configs in the shoots are updated.
+// The trigger is only used if the webhook certificate secret is used, i.e., if a namespace is given.
+type ShootWebhookReconcileTrigger struct {
+	mgr           manager.Manager
+	namespace     string
+	providerType  string
+	caBundle      []byte
+	shootWebhooks *Configs
+	checkInterval time.Duration
+	logger        logr.Logger
+
+	client client.Client
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. The trigger only runs in the leading replica, which
+// also runs the control plane controller, so that the ControlPlanes are not annotated by every replica.
+func (t *ShootWebhookReconcileTrigger) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements manager.Runnable.
+func (t *ShootWebhookReconcileTrigger) Start(stopCh <-chan struct{}) error {
+	if len(t.namespace) == 0 || t.shootWebhooks.IsEmpty() {
+		return nil
+	}
+
+	c, err := getClient(t.mgr)
+	if err != nil {
+		return err
+	}
+	t.client = c
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stopCh
+		cancel()
+	}()
+
+	wait.Until(func() {
+		if err := t.reconcile(ctx); err != nil {
+			t.logger.Error(err, "Could not trigger reconciliation of shoot webhook configurations")
+		}
+	}, t.checkInterval, stopCh)
+	return nil
+}
+
+func (t *ShootWebhookReconcileTrigger) reconcile(ctx context.Context) error {
+	secret := &corev1.Secret{}
+	if err := t.client.Get(ctx, kutil.Key(t.namespace, certSecretName), secret); err != nil {
+		return errors.Wrapf(err, "error getting cert secret")
+	}
+
+	caBundle := caBundleFromSecretData(secret.Data)
+	if bytes.Equal(caBundle, t.caBundle) {
+		return nil
+	}
+
+	// The CA bundle is injected here as well, as the certificate rotator of this replica might not have observed the
+	// rotation yet and the ControlPlanes must be reconciled with the new CA bundle.
+	t.shootWebhooks.InjectCABundle(caBundle)
+
+	t.logger.Info("Triggering reconciliation of shoot webhook configurations")
+	if err := triggerShootWebhookReconciles(ctx, t.client, t.providerType); err != nil {
+		return err
+	}
+	t.caBundle = caBundle
+	return nil
+}
+
+// triggerShootWebhookReconciles annotates all ControlPlanes of the given provider type whose shoot webhook configs have
+// been deployed by the control plane actuator with the reconcile operation, so that the configs are updated with the
+// new CA bundle. As the previous CA is kept in the CA bundle for the trust period, the reconciliations must happen
+// within this period.
+func triggerShootWebhookReconciles(ctx context.Context, c client.Client, providerType string) error {
+	controlPlaneList := &extensionsv1alpha1.ControlPlaneList{}
+	if err := c.List(ctx, controlPlaneList); err != nil {
+		return err
+	}
+
+	for _, cp := range controlPlaneList.Items {
+		if cp.Spec.Type != providerType || (cp.Spec.Purpose != nil && *cp.Spec.Purpose != extensionsv1alpha1.Normal) {
+			continue
+		}
+
+		if err := c.Get(ctx, kutil.Key(cp.Namespace, ShootWebhooksResourceName), &corev1.Secret{}); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		controlPlane := cp.DeepCopy()
+		patch := client.MergeFrom(controlPlane.DeepCopy())
+		kutil.SetMetaDataAnnotation(controlPlane, v1beta1constants.GardenerOperation, v1beta1constants.GardenerOperationReconcile)
+		if err := c.Patch(ctx, controlPlane, patch); err != nil {
+			return errors.Wrapf(err, "error annotating controlplane %s/%s", cp.Namespace, cp.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"crypto/x509"
+	"time"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/utils/secrets"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Certificate rotation", func() {
+	const (
+		namespace = "extension-foo"
+		name      = "provider-foo"
+	)
+
+	var (
+		opts   CertificateRotationOptions
+		secret *corev1.Secret
+		now    time.Time
+	)
+
+	BeforeEach(func() {
+		opts = CertificateRotationOptions{
+			ServerCertRenewBefore: 30 * 24 * time.Hour,
+			CARenewBefore:         90 * 24 * time.Hour,
+			CATrustPeriod:         7 * 24 * time.Hour,
+		}
+
+		caCert, serverCert, err := generateNewCAAndServerCert(ModeService, namespace, name, "")
+		Expect(err).NotTo(HaveOccurred())
+
+		secret = &corev1.Secret{
+			Data: map[string][]byte{
+				secrets.DataKeyCertificateCA: caCert.CertificatePEM,
+				secrets.DataKeyPrivateKeyCA:  caCert.PrivateKeyPEM,
+				secrets.DataKeyCertificate:   serverCert.CertificatePEM,
+				secrets.DataKeyPrivateKey:    serverCert.PrivateKeyPEM,
+			},
+		}
+		now = time.Now()
+	})
+
+	verifyServerCert := func(caPEM []byte) error {
+		pool := x509.NewCertPool()
+		Expect(pool.AppendCertsFromPEM(caPEM)).To(BeTrue())
+		_, serverCert, err := loadExistingCAAndServerCert(secret.Data)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = serverCert.Certificate.Verify(x509.VerifyOptions{Roots: pool})
+		return err
+	}
+
+	Describe("#rotateCertificates", func() {
+		It("should not change anything if the certificates are not about to expire", func() {
+			data := copyData(secret.Data)
+
+			changed, err := rotateCertificates(secret, now, opts, ModeService, namespace, name, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeFalse())
+			Expect(secret.Data).To(Equal(data))
+			Expect(caBundleFromSecretData(secret.Data)).To(Equal(data[secrets.DataKeyCertificateCA]))
+		})
+
+		It("should renew the server certificate if it is about to expire", func() {
+			oldServerCert := secret.Data[secrets.DataKeyCertificate]
+			oldCACert := secret.Data[secrets.DataKeyCertificateCA]
+			opts.CARenewBefore = time.Hour
+
+			changed, err := rotateCertificates(secret, now.AddDate(10, 0, -20), opts, ModeService, namespace, name, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			Expect(secret.Data[secrets.DataKeyCertificate]).NotTo(Equal(oldServerCert))
+			Expect(secret.Data[secrets.DataKeyCertificateCA]).To(Equal(oldCACert))
+			Expect(verifyServerCert(oldCACert)).To(Succeed())
+		})
+
+		It("should rotate the CA with a trust period", func() {
+			var (
+				oldCACert     = secret.Data[secrets.DataKeyCertificateCA]
+				oldServerCert = secret.Data[secrets.DataKeyCertificate]
+				t1            = now.AddDate(10, 0, -60)
+			)
+
+			By("adding the next CA to the bundle")
+			changed, err := rotateCertificates(secret, t1, opts, ModeService, namespace, name, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			nextCACert := secret.Data[dataKeyCertificateCANext]
+			Expect(nextCACert).NotTo(BeEmpty())
+			Expect(secret.Data[secrets.DataKeyCertificate]).To(Equal(oldServerCert))
+			Expect(caBundleFromSecretData(secret.Data)).To(Equal(append(append([]byte{}, oldCACert...), nextCACert...)))
+
+			By("keeping both CAs during the trust period")
+			changed, err = rotateCertificates(secret, t1.Add(24*time.Hour), opts, ModeService, namespace, name, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeFalse())
+
+			By("replacing the CA and renewing the server certificate after the trust period")
+			t2 := t1.Add(8 * 24 * time.Hour)
+			changed, err = rotateCertificates(secret, t2, opts, ModeService, namespace, name, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			Expect(secret.Data[secrets.DataKeyCertificateCA]).To(Equal(nextCACert))
+			Expect(secret.Data[dataKeyCertificateCAPrevious]).To(Equal(oldCACert))
+			Expect(secret.Data).NotTo(HaveKey(dataKeyCertificateCANext))
+			Expect(secret.Data[secrets.DataKeyCertificate]).NotTo(Equal(oldServerCert))
+			Expect(verifyServerCert(nextCACert)).To(Succeed())
+			Expect(verifyServerCert(oldCACert)).NotTo(Succeed())
+
+			By("removing the previous CA after another trust period")
+			opts.CARenewBefore = time.Hour
+			changed, err = rotateCertificates(secret, t2.Add(8*24*time.Hour), opts, ModeService, namespace, name, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			Expect(secret.Data).NotTo(HaveKey(dataKeyCertificateCAPrevious))
+			Expect(caBundleFromSecretData(secret.Data)).To(Equal(nextCACert))
+		})
+	})
+
+	Describe("ShootWebhookReconcileTrigger", func() {
+		var (
+			ctx      = context.TODO()
+			exposure = extensionsv1alpha1.Exposure
+			c        client.Client
+		)
+
+		BeforeEach(func() {
+			s := runtime.NewScheme()
+			Expect(corev1.AddToScheme(s)).To(Succeed())
+			Expect(extensionsv1alpha1.AddToScheme(s)).To(Succeed())
+
+			secret.ObjectMeta = metav1.ObjectMeta{Namespace: namespace, Name: certSecretName}
+			c = fakeclient.NewFakeClientWithScheme(s,
+				secret,
+				&extensionsv1alpha1.ControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "shoot--foo--bar", Name: "bar"}, Spec: extensionsv1alpha1.ControlPlaneSpec{DefaultSpec: extensionsv1alpha1.DefaultSpec{Type: "foo"}}},
+				&extensionsv1alpha1.ControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "shoot--foo--bar", Name: "bar-exposure"}, Spec: extensionsv1alpha1.ControlPlaneSpec{DefaultSpec: extensionsv1alpha1.DefaultSpec{Type: "foo"}, Purpose: &exposure}},
+				&extensionsv1alpha1.ControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "shoot--foo--bar", Name: "bar-other"}, Spec: extensionsv1alpha1.ControlPlaneSpec{DefaultSpec: extensionsv1alpha1.DefaultSpec{Type: "other"}}},
+				&extensionsv1alpha1.ControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "shoot--foo--baz", Name: "baz"}, Spec: extensionsv1alpha1.ControlPlaneSpec{DefaultSpec: extensionsv1alpha1.DefaultSpec{Type: "foo"}}},
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "shoot--foo--bar", Name: ShootWebhooksResourceName}},
+			)
+		})
+
+		expectAnnotated := func(expected map[client.ObjectKey]bool) {
+			for key, annotated := range expected {
+				cp := &extensionsv1alpha1.ControlPlane{}
+				Expect(c.Get(ctx, key, cp)).To(Succeed())
+				if annotated {
+					Expect(cp.Annotations).To(HaveKeyWithValue(v1beta1constants.GardenerOperation, v1beta1constants.GardenerOperationReconcile), key.String())
+				} else {
+					Expect(cp.Annotations).NotTo(HaveKey(v1beta1constants.GardenerOperation), key.String())
+				}
+			}
+		}
+
+		It("should annotate the controlplanes of the provider type with deployed shoot webhooks", func() {
+			Expect(triggerShootWebhookReconciles(ctx, c, "foo")).To(Succeed())
+
+			expectAnnotated(map[client.ObjectKey]bool{
+				{Namespace: "shoot--foo--bar", Name: "bar"}:          true,
+				{Namespace: "shoot--foo--bar", Name: "bar-exposure"}: false,
+				{Namespace: "shoot--foo--bar", Name: "bar-other"}:    false,
+				{Namespace: "shoot--foo--baz", Name: "baz"}:          false,
+			})
+		})
+
+		It("should only trigger the reconciliation if the CA bundle has been rotated", func() {
+			caBundle := caBundleFromSecretData(secret.Data)
+			shootWebhooks := &Configs{MutatingWebhooks: []admissionregistrationv1beta1.MutatingWebhook{{Name: "foo"}}}
+			trigger := NewShootWebhookReconcileTrigger(nil, namespace, "foo", caBundle, shootWebhooks, time.Hour, log.Log.WithName("test"))
+			trigger.client = c
+
+			Expect(trigger.NeedLeaderElection()).To(BeTrue())
+
+			By("not annotating the controlplanes with an unchanged CA bundle")
+			Expect(trigger.reconcile(ctx)).To(Succeed())
+			expectAnnotated(map[client.ObjectKey]bool{{Namespace: "shoot--foo--bar", Name: "bar"}: false})
+
+			By("annotating the controlplanes with a rotated CA bundle")
+			trigger.caBundle = []byte("previous")
+			Expect(trigger.reconcile(ctx)).To(Succeed())
+			expectAnnotated(map[client.ObjectKey]bool{{Namespace: "shoot--foo--bar", Name: "bar"}: true})
+			Expect(trigger.caBundle).To(Equal(caBundle))
+
+			mutatingWebhooks, _ := shootWebhooks.Get()
+			Expect(mutatingWebhooks[0].ClientConfig.CABundle).To(Equal(caBundle))
+		})
+	})
+})
+
+func copyData(data map[string][]byte) map[string][]byte {
+	out := make(map[string][]byte, len(data))
+	for k, v := range data {
+		out[k] = v
+	}
+	return out
+}
@@ -17,13 +17,14 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	extensionswebhook "github.com/gardener/gardener-extensions/pkg/webhook"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
-	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
@@ -34,6 +35,13 @@ const (
 	URLFlag = "webhook-config-url"
 	// NamespaceFlag is the name of the command line flag to specify the webhook config namespace for 'service' mode.
 	NamespaceFlag = "webhook-config-namespace"
+	// SafetyModeFlag is the name of the command line flag to enable the safety mode for 'service' mode.
+	SafetyModeFlag = "webhook-config-safety-mode"
+	// AuditAnnotationsFlag is the name of the command line flag to record the hash of the last mutation of a webhook in
+	// an annotation.
+	AuditAnnotationsFlag = "webhook-audit-annotations"
+	// AuditEventsFlag is the name of the command line flag to record an event for every mutation of a seed webhook.
+	AuditEventsFlag = "webhook-audit-events"
 )
 
 // ServerOptions are command line options that can be set for ServerConfig.
@@ -44,6 +52,15 @@ type ServerOptions struct {
 	URL string
 	// Namespace is the webhook config namespace for 'service' mode.
 	Namespace string
+	// SafetyMode enables switching the failure policy of seed webhooks to Ignore while the webhook server pods are
+	// unhealthy. It is only supported in 'service' mode.
+	SafetyMode bool
+	// AuditAnnotations enables recording the hash of the JSON patch of the last mutation of a webhook in an annotation
+	// of the mutated object.
+	AuditAnnotations bool
+	// AuditEvents enables recording an event containing the JSON patch for every mutation of an existing object by a
+	// seed webhook.
+	AuditEvents bool
 
 	config *ServerConfig
 }
@@ -56,20 +73,36 @@ type ServerConfig struct {
 	URL string
 	// Namespace is the webhook config namespace for 'service' mode.
 	Namespace string
+	// SafetyMode enables switching the failure policy of seed webhooks to Ignore while the webhook server pods are
+	// unhealthy. It is only supported in 'service' mode.
+	SafetyMode bool
+	// AuditAnnotations enables recording the hash of the JSON patch of the last mutation of a webhook in an annotation
+	// of the mutated object.
+	AuditAnnotations bool
+	// AuditEvents enables recording an event containing the JSON patch for every mutation of an existing object by a
+	// seed webhook.
+	AuditEvents bool
 }
 
 // Complete implements Completer.Complete.
 func (w *ServerOptions) Complete() error {
 	w.config = &ServerConfig{
-		Mode:      w.Mode,
-		URL:       w.URL,
-		Namespace: w.Namespace,
+		Mode:             w.Mode,
+		URL:              w.URL,
+		Namespace:        w.Namespace,
+		SafetyMode:       w.SafetyMode,
+		AuditAnnotations: w.AuditAnnotations,
+		AuditEvents:      w.AuditEvents,
 	}
 
 	if len(w.Mode) == 0 {
 		w.config.Mode = extensionswebhook.ModeService
 	}
 
+	if w.config.SafetyMode && w.config.Mode != extensionswebhook.ModeService {
+		return fmt.Errorf("safety mode is only supported in %q mode", extensionswebhook.ModeService)
+	}
+
 	return nil
 }
 
@@ -83,6 +116,9 @@ func (w *ServerOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&w.Mode, ModeFlag, w.Mode, "The webhook mode - either 'url' (when running outside the cluster) or 'service' (when running inside the cluster).")
 	fs.StringVar(&w.URL, URLFlag, w.URL, "The directory that contains the webhook URL when running outside of the cluster it is serving.")
 	fs.StringVar(&w.Namespace, NamespaceFlag, w.Namespace, "The webhook config namespace for 'service' mode.")
+	fs.BoolVar(&w.SafetyMode, SafetyModeFlag, w.SafetyMode, "Switch the failure policy of seed webhooks to 'Ignore' while the webhook server pods are unhealthy ('service' mode only).")
+	fs.BoolVar(&w.AuditAnnotations, AuditAnnotationsFlag, w.AuditAnnotations, "Record the hash of the JSON patch of the last mutation of a webhook in an annotation of the mutated object.")
+	fs.BoolVar(&w.AuditEvents, AuditEventsFlag, w.AuditEvents, "Record an event containing the JSON patch for every mutation of an existing object by a seed webhook.")
 }
 
 // DisableFlag is the name of the command line flag to disable individual webhooks.
@@ -206,7 +242,13 @@ type AddToManagerConfig struct {
 
 // AddToManager instantiates all webhooks of this configuration. If there are any webhooks, it creates a
 // webhook server, registers the webhooks and adds the server to the manager. Otherwise, it is a no-op.
-func (c *AddToManagerConfig) AddToManager(mgr manager.Manager) ([]admissionregistrationv1beta1.MutatingWebhook, []admissionregistrationv1beta1.MutatingWebhook, error) {
+// It also adds a certificate rotator to the manager that renews the webhook certificates before they expire and
+// updates the CA bundle of the returned seed and shoot webhook configs, as well as a trigger that reconciles the
+// ControlPlanes of the provider after the CA bundle has been rotated. If the safety mode is enabled, a guard is added
+// that switches the failure policy of the seed webhooks to Ignore while the webhook server pods are unhealthy.
+// The mutations applied by the webhooks are logged, counted in the webhook metrics and optionally recorded as
+// annotations or (for seed webhooks) events on the mutated objects.
+func (c *AddToManagerConfig) AddToManager(mgr manager.Manager) (*extensionswebhook.Configs, *extensionswebhook.Configs, error) {
 	ctx := context.Background()
 
 	webhooks, err := c.Switch.WebhooksFactory(mgr)
@@ -216,7 +258,13 @@ func (c *AddToManagerConfig) AddToManager(mgr manager.Manager) ([]admissionregis
 
 	webhookServer := mgr.GetWebhookServer()
 
+	auditOpts := extensionswebhook.AuditOptions{Annotate: c.Server.AuditAnnotations}
+	if c.Server.AuditEvents {
+		auditOpts.Recorder = mgr.GetEventRecorderFor("gardener-extension-" + c.serverName + "-webhook")
+	}
+
 	for _, wh := range webhooks {
+		extensionswebhook.InjectAudit(wh, auditOpts)
 		if wh.Handler != nil {
 			webhookServer.Register("/"+wh.Name, wh.Handler)
 		} else {
@@ -234,5 +282,23 @@ func (c *AddToManagerConfig) AddToManager(mgr manager.Manager) ([]admissionregis
 		return nil, nil, errors.Wrap(err, "could not create webhooks")
 	}
 
+	certificateRotator := extensionswebhook.NewCertificateRotator(mgr, webhookServer.CertDir, c.Server.Namespace, c.serverName, c.Server.Mode, c.Server.URL, caBundle, seedWebhooks, shootWebhooks, extensionswebhook.DefaultCertificateRotationOptions, log.Log.WithName("webhook"))
+	if err := mgr.Add(certificateRotator); err != nil {
+		return nil, nil, errors.Wrap(err, "could not add certificate rotator")
+	}
+
+	// The provider type is derived from the server name by the same convention that is used for the webhook names.
+	shootWebhookReconcileTrigger := extensionswebhook.NewShootWebhookReconcileTrigger(mgr, c.Server.Namespace, strings.TrimPrefix(c.serverName, "provider-"), caBundle, shootWebhooks, extensionswebhook.DefaultCertificateRotationOptions.CheckInterval, log.Log.WithName("webhook"))
+	if err := mgr.Add(shootWebhookReconcileTrigger); err != nil {
+		return nil, nil, errors.Wrap(err, "could not add shoot webhook reconcile trigger")
+	}
+
+	if c.Server.SafetyMode {
+		safetyModeGuard := extensionswebhook.NewSafetyModeGuard(mgr, c.Server.Namespace, c.serverName, seedWebhooks, extensionswebhook.DefaultSafetyModeCheckInterval, log.Log.WithName("webhook"))
+		if err := mgr.Add(safetyModeGuard); err != nil {
+			return nil, nil, errors.Wrap(err, "could not add safety mode guard")
+		}
+	}
+
 	return seedWebhooks, shootWebhooks, nil
 }
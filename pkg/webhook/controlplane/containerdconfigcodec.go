@@ -0,0 +1,242 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"bytes"
+
+	"github.com/BurntSushi/toml"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/pkg/errors"
+)
+
+// ContainerdConfig is the containerd configuration. It only models the settings that are typically changed by
+// extensions, all other settings of a decoded configuration are preserved when it is encoded again.
+type ContainerdConfig struct {
+	// Version is the version of the configuration schema.
+	Version *int `toml:"version,omitempty"`
+	// Root is the root directory for containerd metadata.
+	Root string `toml:"root,omitempty"`
+	// State is the state directory for containerd.
+	State string `toml:"state,omitempty"`
+	// OOMScore is the OOM score of the containerd daemon.
+	OOMScore *int `toml:"oom_score,omitempty"`
+	// Plugins contains the configuration of the containerd plugins.
+	Plugins *ContainerdPlugins `toml:"plugins,omitempty"`
+
+	// undecoded contains the settings that are not modelled by this type, in the order of their appearance.
+	undecoded []undecodedEntry
+}
+
+// ContainerdPlugins contains the configuration of the containerd plugins.
+type ContainerdPlugins struct {
+	// CRI is the configuration of the CRI plugin. The codec stores it under the plugin key that matches the version of
+	// the configuration, i.e. 'cri' for version 1 and 'io.containerd.grpc.v1.cri' for version 2.
+	CRI *ContainerdCRIConfig `toml:"cri,omitempty"`
+}
+
+const (
+	// containerdCRIPluginKeyV1 is the key of the CRI plugin in version 1 configurations.
+	containerdCRIPluginKeyV1 = "cri"
+	// containerdCRIPluginKeyV2 is the key of the CRI plugin in version 2 configurations.
+	containerdCRIPluginKeyV2 = "io.containerd.grpc.v1.cri"
+)
+
+// ContainerdCRIConfig is the configuration of the containerd CRI plugin.
+type ContainerdCRIConfig struct {
+	// SandboxImage is the image used by the sandbox (pause) container.
+	SandboxImage string `toml:"sandbox_image,omitempty"`
+	// Containerd contains the containerd specific settings of the CRI plugin.
+	Containerd *ContainerdCRIContainerdConfig `toml:"containerd,omitempty"`
+	// Registry contains the registry settings of the CRI plugin.
+	Registry *ContainerdCRIRegistryConfig `toml:"registry,omitempty"`
+}
+
+// ContainerdCRIContainerdConfig contains the containerd specific settings of the CRI plugin.
+type ContainerdCRIContainerdConfig struct {
+	// Snapshotter is the snapshotter used by containerd.
+	Snapshotter string `toml:"snapshotter,omitempty"`
+	// DefaultRuntimeName is the name of the default runtime.
+	DefaultRuntimeName string `toml:"default_runtime_name,omitempty"`
+	// Runtimes contains the runtimes keyed by their runtime handler name, e.g. for runtime classes.
+	Runtimes map[string]ContainerdRuntime `toml:"runtimes,omitempty"`
+}
+
+// ContainerdRuntime is the configuration of a containerd runtime.
+type ContainerdRuntime struct {
+	// RuntimeType is the runtime type, e.g. 'io.containerd.runc.v1'.
+	RuntimeType string `toml:"runtime_type,omitempty"`
+	// PodAnnotations is the list of pod annotations passed to the runtime.
+	PodAnnotations []string `toml:"pod_annotations,omitempty"`
+	// Options contains runtime specific options.
+	Options map[string]interface{} `toml:"options,omitempty"`
+}
+
+// ContainerdCRIRegistryConfig contains the registry settings of the CRI plugin.
+type ContainerdCRIRegistryConfig struct {
+	// Mirrors contains the registry mirrors keyed by the registry host name.
+	Mirrors map[string]ContainerdRegistryMirror `toml:"mirrors,omitempty"`
+}
+
+// ContainerdRegistryMirror is the configuration of a registry mirror.
+type ContainerdRegistryMirror struct {
+	// Endpoints are the endpoints of the mirror, in the order in which they are tried.
+	Endpoints []string `toml:"endpoint"`
+}
+
+type undecodedEntry struct {
+	key   toml.Key
+	value interface{}
+}
+
+// ContainerdConfigCodec contains methods for encoding and decoding *ContainerdConfig objects
+// to and from *extensionsv1alpha1.FileContentInline.
+type ContainerdConfigCodec interface {
+	// Encode encodes the given *ContainerdConfig into a *extensionsv1alpha1.FileContentInline.
+	Encode(*ContainerdConfig, string) (*extensionsv1alpha1.FileContentInline, error)
+	// Decode decodes a *ContainerdConfig from the given *extensionsv1alpha1.FileContentInline.
+	Decode(*extensionsv1alpha1.FileContentInline) (*ContainerdConfig, error)
+}
+
+// NewContainerdConfigCodec creates an returns a new ContainerdConfigCodec.
+func NewContainerdConfigCodec(fciCodec FileContentInlineCodec) ContainerdConfigCodec {
+	return &containerdConfigCodec{
+		fciCodec: fciCodec,
+	}
+}
+
+type containerdConfigCodec struct {
+	fciCodec FileContentInlineCodec
+}
+
+// Encode encodes the given *ContainerdConfig into a *extensionsv1alpha1.FileContentInline.
+func (c *containerdConfigCodec) Encode(config *ContainerdConfig, encoding string) (*extensionsv1alpha1.FileContentInline, error) {
+	// Encode the modelled settings and convert them into a generic map
+	data, err := encodeTOML(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode containerd configuration to TOML")
+	}
+	m := map[string]interface{}{}
+	if _, err := toml.Decode(string(data), &m); err != nil {
+		return nil, errors.Wrap(err, "could not decode containerd configuration from TOML")
+	}
+
+	// Add the settings that are not modelled
+	for _, entry := range config.undecoded {
+		setUndecoded(m, entry.key, entry.value)
+	}
+
+	// Store the CRI plugin under the key of the configuration version
+	if config.Version != nil && *config.Version >= 2 {
+		renamePlugin(m, containerdCRIPluginKeyV1, containerdCRIPluginKeyV2)
+	}
+
+	if data, err = encodeTOML(m); err != nil {
+		return nil, errors.Wrap(err, "could not encode containerd configuration to TOML")
+	}
+
+	fci, err := c.fciCodec.Encode(data, encoding)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode containerd config file content data")
+	}
+
+	return fci, nil
+}
+
+// Decode decodes a *ContainerdConfig from the given *extensionsv1alpha1.FileContentInline.
+func (c *containerdConfigCodec) Decode(fci *extensionsv1alpha1.FileContentInline) (*ContainerdConfig, error) {
+	data, err := c.fciCodec.Decode(fci)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode containerd config file content data")
+	}
+
+	m := map[string]interface{}{}
+	if _, err := toml.Decode(string(data), &m); err != nil {
+		return nil, errors.Wrap(err, "could not decode containerd configuration from TOML")
+	}
+
+	// Version 2 configurations store the CRI plugin under its fully qualified key, move it to the modelled key
+	if version, ok := m["version"].(int64); ok && version >= 2 {
+		renamePlugin(m, containerdCRIPluginKeyV2, containerdCRIPluginKeyV1)
+		if data, err = encodeTOML(m); err != nil {
+			return nil, errors.Wrap(err, "could not encode containerd configuration to TOML")
+		}
+	}
+
+	// Decode containerd configuration from TOML
+	config := &ContainerdConfig{}
+	md, err := toml.Decode(string(data), config)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode containerd configuration from TOML")
+	}
+
+	// Remember the settings that are not modelled
+	for _, key := range md.Undecoded() {
+		if value, ok := lookup(m, key); ok {
+			config.undecoded = append(config.undecoded, undecodedEntry{key: key, value: value})
+		}
+	}
+
+	return config, nil
+}
+
+func encodeTOML(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// renamePlugin moves the configuration of the plugin with the given key to the given new key, if it exists.
+func renamePlugin(m map[string]interface{}, from, to string) {
+	plugins, ok := m["plugins"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	if value, ok := plugins[from]; ok {
+		delete(plugins, from)
+		plugins[to] = value
+	}
+}
+
+func lookup(m map[string]interface{}, key toml.Key) (interface{}, bool) {
+	var current interface{} = m
+	for _, k := range key {
+		table, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = table[k]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// setUndecoded sets the given value at the given key. The value is only set if its parent table exists, so that
+// settings of tables that were removed from the modelled configuration are not restored.
+func setUndecoded(m map[string]interface{}, key toml.Key, value interface{}) {
+	if len(key) == 0 {
+		return
+	}
+	parent, ok := lookup(m, key[:len(key)-1])
+	if !ok {
+		return
+	}
+	if table, ok := parent.(map[string]interface{}); ok {
+		table[key[len(key)-1]] = value
+	}
+}
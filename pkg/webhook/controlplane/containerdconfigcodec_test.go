@@ -0,0 +1,160 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"github.com/BurntSushi/toml"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContainerdConfigCodec", func() {
+	const data = `version = 2
+root = "/var/lib/containerd"
+
+[grpc]
+  address = "/run/containerd/containerd.sock"
+
+[plugins]
+  [plugins."io.containerd.grpc.v1.cri"]
+    sandbox_image = "k8s.gcr.io/pause:3.1"
+    stream_server_port = "0"
+    [plugins."io.containerd.grpc.v1.cri".containerd]
+      snapshotter = "overlayfs"
+      [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+        runtime_type = "io.containerd.runc.v1"
+        foo = "bar"
+      [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.kata]
+        runtime_type = "io.containerd.kata.v2"
+        foo = "baz"
+  [plugins."io.containerd.runtime.v1.linux"]
+    shim = "containerd-shim"
+`
+
+	const dataV1 = `root = "/var/lib/containerd"
+
+[plugins]
+  [plugins.cri]
+    sandbox_image = "k8s.gcr.io/pause:3.1"
+    stream_server_port = "0"
+  [plugins.linux]
+    shim = "containerd-shim"
+`
+
+	var (
+		codec ContainerdConfigCodec
+		fci   = &extensionsv1alpha1.FileContentInline{Data: data}
+	)
+
+	BeforeEach(func() {
+		codec = NewContainerdConfigCodec(NewFileContentInlineCodec())
+	})
+
+	decode := func(fci *extensionsv1alpha1.FileContentInline) map[string]interface{} {
+		m := map[string]interface{}{}
+		_, err := toml.Decode(fci.Data, &m)
+		Expect(err).NotTo(HaveOccurred())
+		return m
+	}
+
+	Describe("#Decode", func() {
+		It("should decode a ContainerdConfig from the given FileContentInline appropriately", func() {
+			config, err := codec.Decode(fci)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*config.Version).To(Equal(2))
+			Expect(config.Root).To(Equal("/var/lib/containerd"))
+			Expect(config.Plugins.CRI.SandboxImage).To(Equal("k8s.gcr.io/pause:3.1"))
+			Expect(config.Plugins.CRI.Containerd.Snapshotter).To(Equal("overlayfs"))
+			Expect(config.Plugins.CRI.Containerd.Runtimes).To(Equal(map[string]ContainerdRuntime{
+				"runc": {RuntimeType: "io.containerd.runc.v1"},
+				"kata": {RuntimeType: "io.containerd.kata.v2"},
+			}))
+		})
+	})
+
+	Describe("#Encode", func() {
+		It("should encode the given ContainerdConfig and preserve the settings that are not modelled", func() {
+			config, err := codec.Decode(fci)
+			Expect(err).NotTo(HaveOccurred())
+
+			config.Plugins.CRI.SandboxImage = "eu.gcr.io/pause:3.1"
+			config.Plugins.CRI.Registry = &ContainerdCRIRegistryConfig{
+				Mirrors: map[string]ContainerdRegistryMirror{
+					"docker.io": {Endpoints: []string{"https://mirror.example.com"}},
+				},
+			}
+			delete(config.Plugins.CRI.Containerd.Runtimes, "kata")
+
+			newFCI, err := codec.Encode(config, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decode(newFCI)).To(Equal(map[string]interface{}{
+				"version": int64(2),
+				"root":    "/var/lib/containerd",
+				"grpc": map[string]interface{}{
+					"address": "/run/containerd/containerd.sock",
+				},
+				"plugins": map[string]interface{}{
+					"io.containerd.grpc.v1.cri": map[string]interface{}{
+						"sandbox_image":      "eu.gcr.io/pause:3.1",
+						"stream_server_port": "0",
+						"containerd": map[string]interface{}{
+							"snapshotter": "overlayfs",
+							"runtimes": map[string]interface{}{
+								"runc": map[string]interface{}{
+									"runtime_type": "io.containerd.runc.v1",
+									"foo":          "bar",
+								},
+							},
+						},
+						"registry": map[string]interface{}{
+							"mirrors": map[string]interface{}{
+								"docker.io": map[string]interface{}{
+									"endpoint": []interface{}{"https://mirror.example.com"},
+								},
+							},
+						},
+					},
+					"io.containerd.runtime.v1.linux": map[string]interface{}{
+						"shim": "containerd-shim",
+					},
+				},
+			}))
+		})
+
+		It("should encode the given version 1 ContainerdConfig with the version 1 CRI plugin key", func() {
+			config, err := codec.Decode(&extensionsv1alpha1.FileContentInline{Data: dataV1})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Plugins.CRI.SandboxImage).To(Equal("k8s.gcr.io/pause:3.1"))
+
+			config.Plugins.CRI.SandboxImage = "eu.gcr.io/pause:3.1"
+
+			newFCI, err := codec.Encode(config, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decode(newFCI)).To(Equal(map[string]interface{}{
+				"root": "/var/lib/containerd",
+				"plugins": map[string]interface{}{
+					"cri": map[string]interface{}{
+						"sandbox_image":      "eu.gcr.io/pause:3.1",
+						"stream_server_port": "0",
+					},
+					"linux": map[string]interface{}{
+						"shim": "containerd-shim",
+					},
+				},
+			}))
+		})
+	})
+})
@@ -0,0 +1,97 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genericmutator
+
+import (
+	"context"
+
+	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
+	corev1 "k8s.io/api/core/v1"
+	autoscalingv1beta2 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1beta2"
+)
+
+const (
+	// VPANameKubeAPIServer is the name of the kube-apiserver VPA.
+	VPANameKubeAPIServer = "kube-apiserver-vpa"
+	// HPANameKubeAPIServer is the name of the kube-apiserver HPA.
+	HPANameKubeAPIServer = "kube-apiserver"
+	// ContainerNameVPNSeed is the name of the vpn-seed sidecar container of the kube-apiserver deployment.
+	ContainerNameVPNSeed = "vpn-seed"
+
+	// KubeProxyConfigPath is the path of the kube-proxy configuration file in the OperatingSystemConfig.
+	KubeProxyConfigPath = "/var/lib/kube-proxy/config.yaml"
+	// ContainerdConfigPath is the path of the containerd configuration file in the OperatingSystemConfig.
+	ContainerdConfigPath = "/etc/containerd/config.toml"
+	// DockerDaemonConfigPath is the path of the docker daemon configuration file in the OperatingSystemConfig.
+	DockerDaemonConfigPath = "/etc/docker/daemon.json"
+)
+
+// The following interfaces can optionally be implemented by an Ensurer in order to mutate further controlplane objects.
+// They are only invoked if the Ensurer passed to NewMutator implements them, so existing ensurers are not affected.
+
+// ClusterAutoscalerEnsurer ensures that the cluster-autoscaler deployment conforms to the provider requirements.
+type ClusterAutoscalerEnsurer interface {
+	// EnsureClusterAutoscalerDeployment ensures that the cluster-autoscaler deployment conforms to the provider requirements.
+	// "old" might be "nil" and must always be checked.
+	EnsureClusterAutoscalerDeployment(ctx context.Context, ectx EnsurerContext, new, old *appsv1.Deployment) error
+}
+
+// KubeAPIServerAutoscalingEnsurer ensures that the kube-apiserver VPA and HPA conform to the provider requirements.
+type KubeAPIServerAutoscalingEnsurer interface {
+	// EnsureKubeAPIServerVPA ensures that the kube-apiserver VPA conforms to the provider requirements.
+	// "old" might be "nil" and must always be checked.
+	EnsureKubeAPIServerVPA(ctx context.Context, ectx EnsurerContext, new, old *autoscalingv1beta2.VerticalPodAutoscaler) error
+	// EnsureKubeAPIServerHPA ensures that the kube-apiserver HPA conforms to the provider requirements.
+	// "old" might be "nil" and must always be checked.
+	EnsureKubeAPIServerHPA(ctx context.Context, ectx EnsurerContext, new, old *autoscalingv2beta1.HorizontalPodAutoscaler) error
+}
+
+// VPNSeedEnsurer ensures that the vpn-seed sidecar container of the kube-apiserver deployment conforms to the provider
+// requirements.
+type VPNSeedEnsurer interface {
+	// EnsureVPNSeedContainer ensures that the vpn-seed container conforms to the provider requirements.
+	// It is invoked after EnsureKubeAPIServerDeployment. "old" might be "nil" and must always be checked.
+	EnsureVPNSeedContainer(ctx context.Context, ectx EnsurerContext, new, old *corev1.Container) error
+}
+
+// KubeProxyConfigEnsurer ensures that the kube-proxy configuration in the OperatingSystemConfig conforms to the provider
+// requirements.
+type KubeProxyConfigEnsurer interface {
+	// EnsureKubeProxyConfiguration ensures that the kube-proxy configuration conforms to the provider requirements.
+	// "old" might be "nil" and must always be checked.
+	EnsureKubeProxyConfiguration(ctx context.Context, ectx EnsurerContext, new, old *string) error
+}
+
+// ContainerRuntimeConfigEnsurer ensures that the container runtime configuration files in the OperatingSystemConfig
+// conform to the provider requirements.
+type ContainerRuntimeConfigEnsurer interface {
+	// EnsureContainerdConfigFile ensures that the containerd configuration file content conforms to the provider requirements.
+	// "old" might be "nil" and must always be checked.
+	EnsureContainerdConfigFile(ctx context.Context, ectx EnsurerContext, new, old *string) error
+	// EnsureDockerDaemonConfigFile ensures that the docker daemon configuration file content conforms to the provider requirements.
+	// "old" might be "nil" and must always be checked.
+	EnsureDockerDaemonConfigFile(ctx context.Context, ectx EnsurerContext, new, old *string) error
+}
+
+// ContainerdConfigEnsurer ensures that the containerd configuration in the OperatingSystemConfig conforms to the
+// provider requirements, e.g. regarding registry mirrors, runtimes or the sandbox image.
+type ContainerdConfigEnsurer interface {
+	// EnsureContainerdConfig ensures that the containerd configuration conforms to the provider requirements.
+	// It is invoked before EnsureContainerdConfigFile. "old" might be "nil" and must always be checked.
+	EnsureContainerdConfig(ctx context.Context, ectx EnsurerContext, new, old *controlplane.ContainerdConfig) error
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genericmutator_test
+
+import (
+	"context"
+
+	mockcontrolplane "github.com/gardener/gardener-extensions/pkg/mock/gardener-extensions/webhook/controlplane"
+	mockgenericmutator "github.com/gardener/gardener-extensions/pkg/mock/gardener-extensions/webhook/controlplane/genericmutator"
+	extensionswebhook "github.com/gardener/gardener-extensions/pkg/webhook"
+	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane"
+	"github.com/gardener/gardener-extensions/pkg/webhook/controlplane/genericmutator"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	autoscalingv1beta2 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type extendedEnsurer struct {
+	*mockgenericmutator.MockEnsurer
+	*mockgenericmutator.MockClusterAutoscalerEnsurer
+	*mockgenericmutator.MockKubeAPIServerAutoscalingEnsurer
+	*mockgenericmutator.MockVPNSeedEnsurer
+	*mockgenericmutator.MockKubeProxyConfigEnsurer
+	*mockgenericmutator.MockContainerRuntimeConfigEnsurer
+}
+
+type containerdConfigEnsurer struct {
+	*mockgenericmutator.MockEnsurer
+	*mockgenericmutator.MockContainerdConfigEnsurer
+}
+
+var _ = Describe("Optional ensurers", func() {
+	var (
+		ctrl *gomock.Controller
+
+		ensurer *extendedEnsurer
+		fcic    *mockcontrolplane.MockFileContentInlineCodec
+		mutator extensionswebhook.Mutator
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+
+		ensurer = &extendedEnsurer{
+			MockEnsurer:                         mockgenericmutator.NewMockEnsurer(ctrl),
+			MockClusterAutoscalerEnsurer:        mockgenericmutator.NewMockClusterAutoscalerEnsurer(ctrl),
+			MockKubeAPIServerAutoscalingEnsurer: mockgenericmutator.NewMockKubeAPIServerAutoscalingEnsurer(ctrl),
+			MockVPNSeedEnsurer:                  mockgenericmutator.NewMockVPNSeedEnsurer(ctrl),
+			MockKubeProxyConfigEnsurer:          mockgenericmutator.NewMockKubeProxyConfigEnsurer(ctrl),
+			MockContainerRuntimeConfigEnsurer:   mockgenericmutator.NewMockContainerRuntimeConfigEnsurer(ctrl),
+		}
+		fcic = mockcontrolplane.NewMockFileContentInlineCodec(ctrl)
+		mutator = genericmutator.NewMutator(ensurer, mockcontrolplane.NewMockUnitSerializer(ctrl), mockcontrolplane.NewMockKubeletConfigCodec(ctrl), fcic, log.Log.WithName("test"))
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should invoke EnsureClusterAutoscalerDeployment with a cluster-autoscaler deployment", func() {
+		dep := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: v1beta1constants.DeploymentNameClusterAutoscaler}}
+		ensurer.MockClusterAutoscalerEnsurer.EXPECT().EnsureClusterAutoscalerDeployment(context.TODO(), gomock.Any(), dep, nil).Return(nil)
+
+		Expect(mutator.Mutate(context.TODO(), dep, nil)).To(Succeed())
+	})
+
+	It("should invoke EnsureKubeAPIServerVPA with the kube-apiserver VPA", func() {
+		vpa := &autoscalingv1beta2.VerticalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Name: genericmutator.VPANameKubeAPIServer}}
+		old := vpa.DeepCopy()
+		ensurer.MockKubeAPIServerAutoscalingEnsurer.EXPECT().EnsureKubeAPIServerVPA(context.TODO(), gomock.Any(), vpa, old).Return(nil)
+
+		Expect(mutator.Mutate(context.TODO(), vpa, old)).To(Succeed())
+	})
+
+	It("should invoke EnsureKubeAPIServerHPA with the kube-apiserver HPA", func() {
+		hpa := &autoscalingv2beta1.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{Name: genericmutator.HPANameKubeAPIServer}}
+		ensurer.MockKubeAPIServerAutoscalingEnsurer.EXPECT().EnsureKubeAPIServerHPA(context.TODO(), gomock.Any(), hpa, nil).Return(nil)
+
+		Expect(mutator.Mutate(context.TODO(), hpa, nil)).To(Succeed())
+	})
+
+	It("should invoke EnsureVPNSeedContainer after EnsureKubeAPIServerDeployment", func() {
+		dep := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: v1beta1constants.DeploymentNameKubeAPIServer},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{Name: v1beta1constants.DeploymentNameKubeAPIServer},
+							{Name: genericmutator.ContainerNameVPNSeed},
+						},
+					},
+				},
+			},
+		}
+
+		gomock.InOrder(
+			ensurer.MockEnsurer.EXPECT().EnsureKubeAPIServerDeployment(context.TODO(), gomock.Any(), dep, nil).Return(nil),
+			ensurer.MockVPNSeedEnsurer.EXPECT().EnsureVPNSeedContainer(context.TODO(), gomock.Any(), &dep.Spec.Template.Spec.Containers[1], nil).DoAndReturn(
+				func(ctx context.Context, ectx genericmutator.EnsurerContext, new, old *corev1.Container) error {
+					new.Image = "vpn-seed:mutated"
+					return nil
+				},
+			),
+		)
+
+		Expect(mutator.Mutate(context.TODO(), dep, nil)).To(Succeed())
+		Expect(dep.Spec.Template.Spec.Containers[1].Image).To(Equal("vpn-seed:mutated"))
+	})
+
+	It("should invoke the file hooks with the OperatingSystemConfig files", func() {
+		var (
+			file = func(path, data string) extensionsv1alpha1.File {
+				return extensionsv1alpha1.File{
+					Path:    path,
+					Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: data}},
+				}
+			}
+			osc = &extensionsv1alpha1.OperatingSystemConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+				Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+					Purpose: extensionsv1alpha1.OperatingSystemConfigPurposeReconcile,
+					Files: []extensionsv1alpha1.File{
+						file(genericmutator.KubeProxyConfigPath, "proxy"),
+						file(genericmutator.ContainerdConfigPath, "containerd"),
+					},
+				},
+			}
+			mutate = func(suffix string) func(ctx context.Context, ectx genericmutator.EnsurerContext, new, old *string) error {
+				return func(ctx context.Context, ectx genericmutator.EnsurerContext, new, old *string) error {
+					*new = *new + suffix
+					return nil
+				}
+			}
+		)
+
+		ensurer.MockEnsurer.EXPECT().ShouldProvisionKubeletCloudProviderConfig().Return(false)
+		ensurer.MockEnsurer.EXPECT().EnsureAdditionalFiles(context.TODO(), gomock.Any(), &osc.Spec.Files, nil).Return(nil)
+		ensurer.MockEnsurer.EXPECT().EnsureAdditionalUnits(context.TODO(), gomock.Any(), &osc.Spec.Units, nil).Return(nil)
+
+		ensurer.MockKubeProxyConfigEnsurer.EXPECT().EnsureKubeProxyConfiguration(context.TODO(), gomock.Any(), gomock.Any(), nil).DoAndReturn(mutate("-mutated"))
+		ensurer.MockContainerRuntimeConfigEnsurer.EXPECT().EnsureContainerdConfigFile(context.TODO(), gomock.Any(), gomock.Any(), nil).DoAndReturn(mutate("-mutated"))
+
+		fcic.EXPECT().Decode(&extensionsv1alpha1.FileContentInline{Data: "proxy"}).Return([]byte("proxy"), nil)
+		fcic.EXPECT().Encode([]byte("proxy-mutated"), "").Return(&extensionsv1alpha1.FileContentInline{Data: "proxy-mutated"}, nil)
+		fcic.EXPECT().Decode(&extensionsv1alpha1.FileContentInline{Data: "containerd"}).Return([]byte("containerd"), nil)
+		fcic.EXPECT().Encode([]byte("containerd-mutated"), "").Return(&extensionsv1alpha1.FileContentInline{Data: "containerd-mutated"}, nil)
+
+		Expect(mutator.Mutate(context.TODO(), osc, nil)).To(Succeed())
+		Expect(osc.Spec.Files[0].Content.Inline.Data).To(Equal("proxy-mutated"))
+		Expect(osc.Spec.Files[1].Content.Inline.Data).To(Equal("containerd-mutated"))
+	})
+
+	It("should invoke EnsureContainerdConfig with the decoded containerd configuration", func() {
+		var (
+			data = "[plugins]\n  [plugins.cri]\n    sandbox_image = \"pause:3.1\"\n"
+			osc  = &extensionsv1alpha1.OperatingSystemConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+				Spec: extensionsv1alpha1.OperatingSystemConfigSpec{
+					Purpose: extensionsv1alpha1.OperatingSystemConfigPurposeReconcile,
+					Files: []extensionsv1alpha1.File{
+						{
+							Path:    genericmutator.ContainerdConfigPath,
+							Content: extensionsv1alpha1.FileContent{Inline: &extensionsv1alpha1.FileContentInline{Data: data}},
+						},
+					},
+				},
+			}
+			e = &containerdConfigEnsurer{
+				MockEnsurer:                 mockgenericmutator.NewMockEnsurer(ctrl),
+				MockContainerdConfigEnsurer: mockgenericmutator.NewMockContainerdConfigEnsurer(ctrl),
+			}
+		)
+		mutator = genericmutator.NewMutator(e, mockcontrolplane.NewMockUnitSerializer(ctrl), mockcontrolplane.NewMockKubeletConfigCodec(ctrl), controlplane.NewFileContentInlineCodec(), log.Log.WithName("test"))
+
+		e.MockEnsurer.EXPECT().ShouldProvisionKubeletCloudProviderConfig().Return(false)
+		e.MockEnsurer.EXPECT().EnsureAdditionalFiles(context.TODO(), gomock.Any(), &osc.Spec.Files, nil).Return(nil)
+		e.MockEnsurer.EXPECT().EnsureAdditionalUnits(context.TODO(), gomock.Any(), &osc.Spec.Units, nil).Return(nil)
+		e.MockContainerdConfigEnsurer.EXPECT().EnsureContainerdConfig(context.TODO(), gomock.Any(), gomock.Any(), nil).DoAndReturn(
+			func(ctx context.Context, ectx genericmutator.EnsurerContext, new, old *controlplane.ContainerdConfig) error {
+				Expect(new.Plugins.CRI.SandboxImage).To(Equal("pause:3.1"))
+				new.Plugins.CRI.SandboxImage = "pause:3.2"
+				return nil
+			},
+		)
+
+		Expect(mutator.Mutate(context.TODO(), osc, nil)).To(Succeed())
+		Expect(osc.Spec.Files[0].Content.Inline.Data).To(ContainSubstring(`sandbox_image = "pause:3.2"`))
+	})
+})
@@ -30,10 +30,12 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	autoscalingv1beta2 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1beta2"
 	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
@@ -91,21 +93,23 @@ func NewMutator(
 	logger logr.Logger,
 ) extensionswebhook.Mutator {
 	return &mutator{
-		ensurer:            ensurer,
-		unitSerializer:     unitSerializer,
-		kubeletConfigCodec: kubeletConfigCodec,
-		fciCodec:           fciCodec,
-		logger:             logger.WithName("mutator"),
+		ensurer:               ensurer,
+		unitSerializer:        unitSerializer,
+		kubeletConfigCodec:    kubeletConfigCodec,
+		containerdConfigCodec: controlplane.NewContainerdConfigCodec(fciCodec),
+		fciCodec:              fciCodec,
+		logger:                logger.WithName("mutator"),
 	}
 }
 
 type mutator struct {
-	client             client.Client
-	ensurer            Ensurer
-	unitSerializer     controlplane.UnitSerializer
-	kubeletConfigCodec controlplane.KubeletConfigCodec
-	fciCodec           controlplane.FileContentInlineCodec
-	logger             logr.Logger
+	client                client.Client
+	ensurer               Ensurer
+	unitSerializer        controlplane.UnitSerializer
+	kubeletConfigCodec    controlplane.KubeletConfigCodec
+	containerdConfigCodec controlplane.ContainerdConfigCodec
+	fciCodec              controlplane.FileContentInlineCodec
+	logger                logr.Logger
 }
 
 // InjectClient injects the given client into the ensurer.
@@ -163,7 +167,7 @@ func (m *mutator) Mutate(ctx context.Context, new, old runtime.Object) error {
 	}
 	o, ok := new.(metav1.Object)
 	if !ok {
-		return errors.Wrapf(err, "could not cast runtime object to metav1 object")
+		return errors.Errorf("could not cast runtime object to metav1 object")
 	}
 	ectx := NewEnsurerContext(m.client, o)
 
@@ -175,7 +179,7 @@ func (m *mutator) Mutate(ctx context.Context, new, old runtime.Object) error {
 			if old != nil {
 				oldSvc, ok = old.(*corev1.Service)
 				if !ok {
-					return errors.Wrapf(err, "could not cast old object to corev1.Service")
+					return errors.Errorf("could not cast old object to corev1.Service")
 				}
 			}
 
@@ -187,20 +191,54 @@ func (m *mutator) Mutate(ctx context.Context, new, old runtime.Object) error {
 		if old != nil {
 			oldDep, ok = old.(*appsv1.Deployment)
 			if !ok {
-				return errors.Wrapf(err, "could not cast old object to appsv1.Deployment")
+				return errors.Errorf("could not cast old object to appsv1.Deployment")
 			}
 		}
 
 		switch x.Name {
 		case v1beta1constants.DeploymentNameKubeAPIServer:
 			extensionswebhook.LogMutation(m.logger, x.Kind, x.Namespace, x.Name)
-			return m.ensurer.EnsureKubeAPIServerDeployment(ctx, ectx, x, oldDep)
+			if err := m.ensurer.EnsureKubeAPIServerDeployment(ctx, ectx, x, oldDep); err != nil {
+				return err
+			}
+			return m.ensureVPNSeedContainer(ctx, ectx, x, oldDep)
 		case v1beta1constants.DeploymentNameKubeControllerManager:
 			extensionswebhook.LogMutation(m.logger, x.Kind, x.Namespace, x.Name)
 			return m.ensurer.EnsureKubeControllerManagerDeployment(ctx, ectx, x, oldDep)
 		case v1beta1constants.DeploymentNameKubeScheduler:
 			extensionswebhook.LogMutation(m.logger, x.Kind, x.Namespace, x.Name)
 			return m.ensurer.EnsureKubeSchedulerDeployment(ctx, ectx, x, oldDep)
+		case v1beta1constants.DeploymentNameClusterAutoscaler:
+			if e, ok := m.ensurer.(ClusterAutoscalerEnsurer); ok {
+				extensionswebhook.LogMutation(m.logger, x.Kind, x.Namespace, x.Name)
+				return e.EnsureClusterAutoscalerDeployment(ctx, ectx, x, oldDep)
+			}
+		}
+	case *autoscalingv1beta2.VerticalPodAutoscaler:
+		if e, ok := m.ensurer.(KubeAPIServerAutoscalingEnsurer); ok && x.Name == VPANameKubeAPIServer {
+			var oldVPA *autoscalingv1beta2.VerticalPodAutoscaler
+			if old != nil {
+				oldVPA, ok = old.(*autoscalingv1beta2.VerticalPodAutoscaler)
+				if !ok {
+					return errors.Errorf("could not cast old object to autoscalingv1beta2.VerticalPodAutoscaler")
+				}
+			}
+
+			extensionswebhook.LogMutation(m.logger, x.Kind, x.Namespace, x.Name)
+			return e.EnsureKubeAPIServerVPA(ctx, ectx, x, oldVPA)
+		}
+	case *autoscalingv2beta1.HorizontalPodAutoscaler:
+		if e, ok := m.ensurer.(KubeAPIServerAutoscalingEnsurer); ok && x.Name == HPANameKubeAPIServer {
+			var oldHPA *autoscalingv2beta1.HorizontalPodAutoscaler
+			if old != nil {
+				oldHPA, ok = old.(*autoscalingv2beta1.HorizontalPodAutoscaler)
+				if !ok {
+					return errors.Errorf("could not cast old object to autoscalingv2beta1.HorizontalPodAutoscaler")
+				}
+			}
+
+			extensionswebhook.LogMutation(m.logger, x.Kind, x.Namespace, x.Name)
+			return e.EnsureKubeAPIServerHPA(ctx, ectx, x, oldHPA)
 		}
 	case *druidv1alpha1.Etcd:
 		switch x.Name {
@@ -209,7 +247,7 @@ func (m *mutator) Mutate(ctx context.Context, new, old runtime.Object) error {
 			if old != nil {
 				oldEtcd, ok = old.(*druidv1alpha1.Etcd)
 				if !ok {
-					return errors.Wrapf(err, "could not cast old object to druidv1alpha1.Etcd")
+					return errors.Errorf("could not cast old object to druidv1alpha1.Etcd")
 				}
 			}
 
@@ -222,7 +260,7 @@ func (m *mutator) Mutate(ctx context.Context, new, old runtime.Object) error {
 			if old != nil {
 				oldOSC, ok = old.(*extensionsv1alpha1.OperatingSystemConfig)
 				if !ok {
-					return errors.Wrapf(err, "could not cast old object to extensionsv1alpha1.OperatingSystemConfig")
+					return errors.Errorf("could not cast old object to extensionsv1alpha1.OperatingSystemConfig")
 				}
 			}
 
@@ -284,6 +322,32 @@ func (m *mutator) mutateOperatingSystemConfig(ctx context.Context, ectx EnsurerC
 		}
 	}
 
+	// Mutate kube-proxy configuration file, if present and supported by the ensurer
+	if e, ok := m.ensurer.(KubeProxyConfigEnsurer); ok {
+		if err := m.ensureFileContent(ctx, ectx, osc, oldOSC, KubeProxyConfigPath, "kube-proxy configuration", e.EnsureKubeProxyConfiguration); err != nil {
+			return err
+		}
+	}
+
+	// Mutate containerd configuration file, if present and supported by the ensurer
+	if e, ok := m.ensurer.(ContainerdConfigEnsurer); ok {
+		if content := findFileWithPath(osc, ContainerdConfigPath); content != nil {
+			if err := m.ensureContainerdConfigFileContent(ctx, ectx, e, content, findFileWithPath(oldOSC, ContainerdConfigPath)); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Mutate container runtime configuration files, if present and supported by the ensurer
+	if e, ok := m.ensurer.(ContainerRuntimeConfigEnsurer); ok {
+		if err := m.ensureFileContent(ctx, ectx, osc, oldOSC, ContainerdConfigPath, "containerd configuration", e.EnsureContainerdConfigFile); err != nil {
+			return err
+		}
+		if err := m.ensureFileContent(ctx, ectx, osc, oldOSC, DockerDaemonConfigPath, "docker daemon configuration", e.EnsureDockerDaemonConfigFile); err != nil {
+			return err
+		}
+	}
+
 	// Check if cloud provider config needs to be ensured
 	if m.ensurer.ShouldProvisionKubeletCloudProviderConfig() {
 		if err := m.ensureKubeletCloudProviderConfig(ctx, ectx, osc); err != nil {
@@ -374,6 +438,38 @@ func (m *mutator) ensureKubeletConfigFileContent(ctx context.Context, ectx Ensur
 	return nil
 }
 
+func (m *mutator) ensureContainerdConfigFileContent(ctx context.Context, ectx EnsurerContext, e ContainerdConfigEnsurer, fci, oldFCI *extensionsv1alpha1.FileContentInline) error {
+	var (
+		containerdConfig, oldContainerdConfig *controlplane.ContainerdConfig
+		err                                   error
+	)
+
+	// Decode containerd configuration from inline content
+	if containerdConfig, err = m.containerdConfigCodec.Decode(fci); err != nil {
+		return errors.Wrap(err, "could not decode containerd configuration")
+	}
+
+	if oldFCI != nil {
+		// Decode old containerd configuration from inline content
+		if oldContainerdConfig, err = m.containerdConfigCodec.Decode(oldFCI); err != nil {
+			return errors.Wrap(err, "could not decode old containerd configuration")
+		}
+	}
+
+	if err = e.EnsureContainerdConfig(ctx, ectx, containerdConfig, oldContainerdConfig); err != nil {
+		return err
+	}
+
+	// Encode containerd configuration into inline content
+	var newFCI *extensionsv1alpha1.FileContentInline
+	if newFCI, err = m.containerdConfigCodec.Encode(containerdConfig, fci.Encoding); err != nil {
+		return errors.Wrap(err, "could not encode containerd configuration")
+	}
+	*fci = *newFCI
+
+	return nil
+}
+
 func (m *mutator) ensureKubernetesGeneralConfiguration(ctx context.Context, ectx EnsurerContext, fci, oldFCI *extensionsv1alpha1.FileContentInline) error {
 	var (
 		data, oldData []byte
@@ -408,6 +504,64 @@ func (m *mutator) ensureKubernetesGeneralConfiguration(ctx context.Context, ectx
 	return nil
 }
 
+func (m *mutator) ensureVPNSeedContainer(ctx context.Context, ectx EnsurerContext, dep, oldDep *appsv1.Deployment) error {
+	e, ok := m.ensurer.(VPNSeedEnsurer)
+	if !ok {
+		return nil
+	}
+
+	c := extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, ContainerNameVPNSeed)
+	if c == nil {
+		return nil
+	}
+
+	var oldC *corev1.Container
+	if oldDep != nil {
+		oldC = extensionswebhook.ContainerWithName(oldDep.Spec.Template.Spec.Containers, ContainerNameVPNSeed)
+	}
+
+	return e.EnsureVPNSeedContainer(ctx, ectx, c, oldC)
+}
+
+type ensureStringFunc func(ctx context.Context, ectx EnsurerContext, new, old *string) error
+
+// ensureFileContent invokes the given function with the decoded content of the file with the given path, if this file
+// is present in the OperatingSystemConfig, and encodes the result back into the file.
+func (m *mutator) ensureFileContent(ctx context.Context, ectx EnsurerContext, osc, oldOSC *extensionsv1alpha1.OperatingSystemConfig, path, description string, ensure ensureStringFunc) error {
+	fci := findFileWithPath(osc, path)
+	if fci == nil {
+		return nil
+	}
+
+	data, err := m.fciCodec.Decode(fci)
+	if err != nil {
+		return errors.Wrapf(err, "could not decode %s", description)
+	}
+
+	var oldS *string
+	if oldFCI := findFileWithPath(oldOSC, path); oldFCI != nil {
+		oldData, err := m.fciCodec.Decode(oldFCI)
+		if err != nil {
+			return errors.Wrapf(err, "could not decode old %s", description)
+		}
+		s := string(oldData)
+		oldS = &s
+	}
+
+	s := string(data)
+	if err := ensure(ctx, ectx, &s, oldS); err != nil {
+		return err
+	}
+
+	newFCI, err := m.fciCodec.Encode([]byte(s), fci.Encoding)
+	if err != nil {
+		return errors.Wrapf(err, "could not encode %s", description)
+	}
+	*fci = *newFCI
+
+	return nil
+}
+
 const CloudProviderConfigPath = "/var/lib/kubelet/cloudprovider.conf"
 
 func (m *mutator) ensureKubeletCloudProviderConfig(ctx context.Context, ectx EnsurerContext, osc *extensionsv1alpha1.OperatingSystemConfig) error {
@@ -155,6 +155,11 @@ var _ = Describe("Mutator", func() {
 			),
 		)
 
+		It("should return an error if the old object has a different type", func() {
+			err := mutator.Mutate(context.TODO(), &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "test"}}, &corev1.Service{})
+			Expect(err).To(MatchError("could not cast old object to appsv1.Deployment"))
+		})
+
 		DescribeTable("Should ensure", func(ensureFunc func()) {
 			ensureFunc()
 
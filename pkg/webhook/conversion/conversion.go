@@ -0,0 +1,59 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"fmt"
+
+	extensionswebhook "github.com/gardener/gardener-extensions/pkg/webhook"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// WebhookName is the name of the conversion webhook.
+	WebhookName = "conversion"
+)
+
+var logger = log.Log.WithName("conversion-webhook")
+
+// AddArgs are arguments for adding a conversion webhook to a manager.
+type AddArgs struct {
+	// Provider is the provider of this webhook.
+	Provider string
+	// Converter is the converter to be used by the conversion handler.
+	Converter *Converter
+}
+
+// Add creates a new conversion webhook that serves ConversionReview requests for the API groups registered in the
+// given converter. The webhook is only served and not registered in any webhook configuration.
+func Add(mgr manager.Manager, args AddArgs) (*extensionswebhook.Webhook, error) {
+	logger := logger.WithValues("provider", args.Provider)
+
+	if args.Converter == nil {
+		return nil, fmt.Errorf("converter is not set")
+	}
+
+	logger.Info("Creating webhook", "name", WebhookName)
+	return &extensionswebhook.Webhook{
+		Name:     WebhookName,
+		Provider: args.Provider,
+		Path:     WebhookName,
+		Target:   extensionswebhook.TargetSeed,
+		Action:   extensionswebhook.ActionConversion,
+		Handler:  NewHandler(args.Converter, logger),
+	}, nil
+}
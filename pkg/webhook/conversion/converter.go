@@ -0,0 +1,148 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Object is an extension resource whose provider config and provider status can be migrated.
+type Object interface {
+	runtime.Object
+	extensionsv1alpha1.Object
+}
+
+// Converter converts objects of the registered provider API groups between their versions, using the conversion
+// functions registered in the given scheme. Objects are always converted via the internal version of their group,
+// hence the scheme must contain the internal types. The newest version of a group is the first one of its prioritized
+// versions, so providers should set the version priority of their groups in the scheme.
+type Converter struct {
+	scheme *runtime.Scheme
+	codecs serializer.CodecFactory
+	groups sets.String
+}
+
+// NewConverter creates a new Converter for the given API groups, using the given scheme.
+func NewConverter(scheme *runtime.Scheme, groups ...string) *Converter {
+	return &Converter{
+		scheme: scheme,
+		codecs: serializer.NewCodecFactory(scheme),
+		groups: sets.NewString(groups...),
+	}
+}
+
+// Convert converts the given raw object into the given group version and returns the raw result.
+func (c *Converter) Convert(raw []byte, gv schema.GroupVersion) ([]byte, error) {
+	if !c.groups.Has(gv.Group) {
+		return nil, fmt.Errorf("group %q is not registered for conversion", gv.Group)
+	}
+
+	// Decode the object into the internal version
+	obj, gvk, err := c.codecs.UniversalDecoder().Decode(raw, nil, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not decode object")
+	}
+	if gvk.Group != gv.Group {
+		return nil, fmt.Errorf("cannot convert object of group %q into group %q", gvk.Group, gv.Group)
+	}
+
+	// Encode the internal object into the given version
+	out, err := runtime.Encode(c.codecs.LegacyCodec(gv), obj)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not encode object into version %s", gv.String())
+	}
+
+	return bytes.TrimSpace(out), nil
+}
+
+// PreferredVersion returns the newest version of the given group.
+func (c *Converter) PreferredVersion(group string) (schema.GroupVersion, error) {
+	versions := c.scheme.PrioritizedVersionsForGroup(group)
+	if !c.groups.Has(group) || len(versions) == 0 {
+		return schema.GroupVersion{}, fmt.Errorf("group %q is not registered for conversion", group)
+	}
+	return versions[0], nil
+}
+
+// ConvertToPreferredVersion converts the given raw extension into the newest version of its group in place. It returns
+// true if the raw extension has been changed. Raw extensions of groups that are not registered are left untouched.
+func (c *Converter) ConvertToPreferredVersion(ext *runtime.RawExtension) (bool, error) {
+	if ext == nil || len(ext.Raw) == 0 {
+		return false, nil
+	}
+
+	gvk, err := json.DefaultMetaFactory.Interpret(ext.Raw)
+	if err != nil {
+		return false, errors.Wrapf(err, "could not determine the version of the object")
+	}
+	if !c.groups.Has(gvk.Group) {
+		return false, nil
+	}
+
+	preferred, err := c.PreferredVersion(gvk.Group)
+	if err != nil {
+		return false, err
+	}
+	if gvk.GroupVersion() == preferred {
+		return false, nil
+	}
+
+	raw, err := c.Convert(ext.Raw, preferred)
+	if err != nil {
+		return false, err
+	}
+
+	ext.Raw = raw
+	ext.Object = nil
+	return true, nil
+}
+
+// MigrateObject converts the provider config and the provider status of the given extension resource into the newest
+// versions of their groups. A converted provider status is written back to the cluster. The provider config is only
+// converted in memory as the spec of extension resources is owned by Gardener. MigrateObject should be called at the
+// beginning of a reconciliation, before the provider config and the provider status are decoded.
+func (c *Converter) MigrateObject(ctx context.Context, cl client.Client, obj Object) error {
+	if status := obj.GetExtensionStatus().GetProviderStatus(); status != nil {
+		if changed, err := c.ConvertToPreferredVersion(status.DeepCopy()); err != nil {
+			return errors.Wrapf(err, "could not convert provider status")
+		} else if changed {
+			if err := extensionscontroller.TryUpdateStatus(ctx, retry.DefaultBackoff, cl, obj, func() error {
+				_, err := c.ConvertToPreferredVersion(obj.GetExtensionStatus().GetProviderStatus())
+				return err
+			}); err != nil {
+				return errors.Wrapf(err, "could not update provider status")
+			}
+		}
+	}
+
+	if _, err := c.ConvertToPreferredVersion(obj.GetExtensionSpec().GetProviderConfig()); err != nil {
+		return errors.Wrapf(err, "could not convert provider config")
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/gardener/gardener-extensions/pkg/webhook/conversion"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const group = "test.provider.extensions.gardener.cloud"
+
+var (
+	internalGV = schema.GroupVersion{Group: group, Version: runtime.APIVersionInternal}
+	v1alpha1GV = schema.GroupVersion{Group: group, Version: "v1alpha1"}
+	v1alpha2GV = schema.GroupVersion{Group: group, Version: "v1alpha2"}
+)
+
+var _ = Describe("Converter", func() {
+	var (
+		converter *Converter
+
+		v1alpha1Config = []byte(`{"apiVersion":"test.provider.extensions.gardener.cloud/v1alpha1","kind":"Config","value":"foo"}`)
+		v1alpha2Config = []byte(`{"kind":"Config","apiVersion":"test.provider.extensions.gardener.cloud/v1alpha2","data":"foo"}`)
+	)
+
+	BeforeEach(func() {
+		converter = NewConverter(newScheme(), group)
+	})
+
+	Describe("#Convert", func() {
+		It("should convert the object into the given version", func() {
+			raw, err := converter.Convert(v1alpha1Config, v1alpha2GV)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(raw).To(MatchJSON(v1alpha2Config))
+		})
+
+		It("should fail for groups that are not registered", func() {
+			_, err := converter.Convert(v1alpha1Config, schema.GroupVersion{Group: "foo", Version: "v1"})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#ConvertToPreferredVersion", func() {
+		It("should convert the raw extension into the newest version", func() {
+			ext := &runtime.RawExtension{Raw: v1alpha1Config}
+
+			changed, err := converter.ConvertToPreferredVersion(ext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			Expect(ext.Raw).To(MatchJSON(v1alpha2Config))
+		})
+
+		It("should not change raw extensions that already have the newest version", func() {
+			ext := &runtime.RawExtension{Raw: v1alpha2Config}
+
+			changed, err := converter.ConvertToPreferredVersion(ext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeFalse())
+			Expect(ext.Raw).To(Equal(v1alpha2Config))
+		})
+
+		It("should not change raw extensions of other groups", func() {
+			raw := []byte(`{"apiVersion":"other.gardener.cloud/v1","kind":"Config"}`)
+			ext := &runtime.RawExtension{Raw: raw}
+
+			changed, err := converter.ConvertToPreferredVersion(ext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeFalse())
+			Expect(ext.Raw).To(Equal(raw))
+		})
+	})
+
+	Describe("#ServeHTTP", func() {
+		It("should convert the objects of the conversion review", func() {
+			review := &apiextensionsv1beta1.ConversionReview{
+				TypeMeta: metav1.TypeMeta{APIVersion: "apiextensions.k8s.io/v1beta1", Kind: "ConversionReview"},
+				Request: &apiextensionsv1beta1.ConversionRequest{
+					UID:               "1234",
+					DesiredAPIVersion: v1alpha2GV.String(),
+					Objects:           []runtime.RawExtension{{Raw: v1alpha1Config}},
+				},
+			}
+			body, err := json.Marshal(review)
+			Expect(err).NotTo(HaveOccurred())
+
+			req := httptest.NewRequest(http.MethodPost, "/conversion", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			NewHandler(converter, log.Log).ServeHTTP(rec, req)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			result := &apiextensionsv1beta1.ConversionReview{}
+			Expect(json.Unmarshal(rec.Body.Bytes(), result)).To(Succeed())
+			Expect(result.Response.UID).To(BeEquivalentTo("1234"))
+			Expect(result.Response.Result.Status).To(Equal(metav1.StatusSuccess))
+			Expect(result.Response.ConvertedObjects).To(HaveLen(1))
+			Expect(result.Response.ConvertedObjects[0].Raw).To(MatchJSON(v1alpha2Config))
+		})
+	})
+})
+
+type config struct {
+	metav1.TypeMeta
+	Value string
+}
+
+func (c *config) DeepCopyObject() runtime.Object { out := *c; return &out }
+
+type configV1alpha1 struct {
+	metav1.TypeMeta `json:",inline"`
+	Value           string `json:"value"`
+}
+
+func (c *configV1alpha1) DeepCopyObject() runtime.Object { out := *c; return &out }
+
+type configV1alpha2 struct {
+	metav1.TypeMeta `json:",inline"`
+	Data            string `json:"data"`
+}
+
+func (c *configV1alpha2) DeepCopyObject() runtime.Object { out := *c; return &out }
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypeWithName(internalGV.WithKind("Config"), &config{})
+	scheme.AddKnownTypeWithName(v1alpha1GV.WithKind("Config"), &configV1alpha1{})
+	scheme.AddKnownTypeWithName(v1alpha2GV.WithKind("Config"), &configV1alpha2{})
+	Expect(scheme.SetVersionPriority(v1alpha2GV, v1alpha1GV)).To(Succeed())
+
+	Expect(scheme.AddConversionFunc((*configV1alpha1)(nil), (*config)(nil), func(a, b interface{}, _ conversion.Scope) error {
+		b.(*config).Value = a.(*configV1alpha1).Value
+		return nil
+	})).To(Succeed())
+	Expect(scheme.AddConversionFunc((*config)(nil), (*configV1alpha1)(nil), func(a, b interface{}, _ conversion.Scope) error {
+		b.(*configV1alpha1).Value = a.(*config).Value
+		return nil
+	})).To(Succeed())
+	Expect(scheme.AddConversionFunc((*configV1alpha2)(nil), (*config)(nil), func(a, b interface{}, _ conversion.Scope) error {
+		b.(*config).Value = a.(*configV1alpha2).Data
+		return nil
+	})).To(Succeed())
+	Expect(scheme.AddConversionFunc((*config)(nil), (*configV1alpha2)(nil), func(a, b interface{}, _ conversion.Scope) error {
+		b.(*configV1alpha2).Data = a.(*config).Value
+		return nil
+	})).To(Succeed())
+
+	return scheme
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conversion
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// NewHandler creates a new handler that serves ConversionReview requests using the given converter.
+func NewHandler(converter *Converter, logger logr.Logger) http.Handler {
+	return &handler{
+		converter: converter,
+		logger:    logger.WithName("handler"),
+	}
+}
+
+type handler struct {
+	converter *Converter
+	logger    logr.Logger
+}
+
+// ServeHTTP is a handler for serving an HTTP endpoint that is used for conversion webhooks.
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Body == nil {
+		err := errors.New("request body is empty")
+		h.logger.Error(err, "bad request")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// verify the content type is accurate
+	if contentType := r.Header.Get("Content-Type"); contentType != "application/json" {
+		err := fmt.Errorf("contentType=%s, expected application/json", contentType)
+		h.logger.Error(err, "unable to process a request with an unknown content type", "content type", contentType)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	review := &apiextensionsv1beta1.ConversionReview{}
+	if err := json.NewDecoder(r.Body).Decode(review); err != nil {
+		h.logger.Error(err, "unable to decode the request")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		err := errors.New("conversion request is empty")
+		h.logger.Error(err, "bad request")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	review.Response = h.convert(review.Request)
+	review.Response.UID = review.Request.UID
+	review.Request = nil
+
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		h.logger.Error(err, "unable to encode the response")
+	}
+}
+
+func (h *handler) convert(req *apiextensionsv1beta1.ConversionRequest) *apiextensionsv1beta1.ConversionResponse {
+	gv, err := schema.ParseGroupVersion(req.DesiredAPIVersion)
+	if err != nil {
+		return conversionFailed(errors.Wrapf(err, "could not parse desired API version %q", req.DesiredAPIVersion))
+	}
+
+	convertedObjects := make([]runtime.RawExtension, 0, len(req.Objects))
+	for i, obj := range req.Objects {
+		raw, err := h.converter.Convert(obj.Raw, gv)
+		if err != nil {
+			return conversionFailed(errors.Wrapf(err, "could not convert object %d", i))
+		}
+		convertedObjects = append(convertedObjects, runtime.RawExtension{Raw: raw})
+	}
+
+	return &apiextensionsv1beta1.ConversionResponse{
+		ConvertedObjects: convertedObjects,
+		Result:           metav1.Status{Status: metav1.StatusSuccess},
+	}
+}
+
+func conversionFailed(err error) *apiextensionsv1beta1.ConversionResponse {
+	return &apiextensionsv1beta1.ConversionResponse{
+		Result: metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: err.Error(),
+		},
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"net/http"
 
 	"github.com/pkg/errors"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
@@ -30,6 +31,18 @@ const (
 	TargetSeed = "seed"
 	// TargetShoot defines that the webhook is to be installed in the shoot.
 	TargetShoot = "shoot"
+
+	// ActionMutating defines that the webhook is registered as a mutating webhook.
+	ActionMutating = "mutating"
+	// ActionValidating defines that the webhook is registered as a validating webhook.
+	ActionValidating = "validating"
+	// ActionConversion defines that the webhook is a conversion webhook. Conversion webhooks are only served and not
+	// registered in any webhook configuration.
+	ActionConversion = "conversion"
+
+	// ValidatingSuffix is appended to the name and path of validating webhooks created by the webhook helpers in order
+	// to distinguish them from their mutating counterparts.
+	ValidatingSuffix = "-validation"
 )
 
 // Webhook is the specification of a webhook.
@@ -39,10 +52,23 @@ type Webhook struct {
 	Provider string
 	Path     string
 	Target   string
+	// Action is either ActionMutating, ActionValidating or ActionConversion. If empty, ActionMutating is assumed.
+	Action   string
 	Types    []runtime.Object
 	Webhook  *admission.Webhook
 	Handler  http.Handler
 	Selector *metav1.LabelSelector
+
+	// FailurePolicy is the failure policy of the webhook. If not set, Fail is used for seed webhooks and Ignore for
+	// shoot webhooks.
+	FailurePolicy *admissionregistrationv1beta1.FailurePolicyType
+	// TimeoutSeconds is the timeout of the webhook. If not set, the default of the kube-apiserver is used.
+	TimeoutSeconds *int32
+	// SideEffects states whether the webhook has side effects. If not set, the default of the kube-apiserver is used.
+	SideEffects *admissionregistrationv1beta1.SideEffectClass
+	// ReinvocationPolicy is the reinvocation policy of the webhook. It is only used for mutating webhooks. If not set,
+	// the default of the kube-apiserver is used.
+	ReinvocationPolicy *admissionregistrationv1beta1.ReinvocationPolicyType
 }
 
 // FactoryAggregator aggregates various Factory functions.
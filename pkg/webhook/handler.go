@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -51,6 +52,7 @@ type handler struct {
 	typesMap map[metav1.GroupVersionKind]runtime.Object
 	mutator  Mutator
 	decoder  *admission.Decoder
+	audit    auditor
 	logger   logr.Logger
 }
 
@@ -69,49 +71,34 @@ func (h *handler) InjectClient(client client.Client) error {
 	return nil
 }
 
+func (h *handler) injectAudit(webhook string, opts AuditOptions) {
+	h.audit = auditor{webhook: webhook, opts: opts}
+}
+
 // Handle handles the given admission request.
 func (h *handler) Handle(ctx context.Context, req admission.Request) admission.Response {
 	f := func(ctx context.Context, new, old runtime.Object, r *http.Request) error {
 		return h.mutator.Mutate(ctx, new, old)
 	}
-	return handle(ctx, req, nil, f, h.typesMap, h.decoder, h.logger)
+	return handle(ctx, req, nil, f, h.typesMap, h.decoder, &h.audit, h.logger)
 }
 
 type mutateFunc func(ctx context.Context, new, old runtime.Object, r *http.Request) error
 
-func handle(ctx context.Context, req admission.Request, r *http.Request, f mutateFunc, typesMap map[metav1.GroupVersionKind]runtime.Object, decoder *admission.Decoder, logger logr.Logger) admission.Response {
+func handle(ctx context.Context, req admission.Request, r *http.Request, f mutateFunc, typesMap map[metav1.GroupVersionKind]runtime.Object, decoder *admission.Decoder, audit *auditor, logger logr.Logger) admission.Response {
 	ar := req.AdmissionRequest
 
-	// Decode object
-	t, ok := typesMap[ar.Kind]
-	if !ok {
-		return admission.Errored(http.StatusBadRequest, errors.Errorf("unexpected request kind %s", ar.Kind.String()))
-	}
-	obj := t.DeepCopyObject()
-	err := decoder.Decode(req, obj)
-	if err != nil {
-		return admission.Errored(http.StatusBadRequest, errors.Wrapf(err, "could not decode request %v", ar))
-	}
-
-	// Get object accessor
-	accessor, err := meta.Accessor(obj)
-	if err != nil {
-		return admission.Errored(http.StatusBadRequest, errors.Wrapf(err, "could not get accessor for %v", obj))
-	}
-
-	var oldObj runtime.Object
-
-	// Only UPDATE and DELETE operations have oldObjects.
-	if len(req.OldObject.Raw) != 0 {
-		oldObj = t.DeepCopyObject()
-		if err := decoder.DecodeRaw(ar.OldObject, oldObj); err != nil {
-			return admission.Errored(http.StatusBadRequest, errors.Wrapf(err, "could not decode old object %v", oldObj))
-		}
+	obj, oldObj, accessor, errResp := decodeRequest(req, typesMap, decoder)
+	if errResp != nil {
+		return *errResp
 	}
 
 	// Mutate the resource
 	newObj := obj.DeepCopyObject()
-	if err = f(ctx, newObj, oldObj, r); err != nil {
+	start := time.Now()
+	err := f(ctx, newObj, oldObj, r)
+	mutationDuration.WithLabelValues(audit.webhook).Observe(time.Since(start).Seconds())
+	if err != nil {
 		return admission.Errored(http.StatusInternalServerError,
 			errors.Wrapf(err, "could not mutate %s %s/%s", ar.Kind.Kind, accessor.GetNamespace(), accessor.GetName()))
 	}
@@ -127,9 +114,56 @@ func handle(ctx context.Context, req admission.Request, r *http.Request, f mutat
 			return admission.Errored(http.StatusInternalServerError, err)
 		}
 
-		return admission.PatchResponseFromRaw(oldObjMarshaled, newObjMarshaled)
+		resp := admission.PatchResponseFromRaw(oldObjMarshaled, newObjMarshaled)
+		if !resp.Allowed {
+			return resp
+		}
+
+		newAccessor, err := meta.Accessor(newObj)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		return audit.record(resp, ar.Kind.Kind, oldObjMarshaled, newObj, newAccessor, logger)
 	}
 
 	// Return a validation response if the resource should not be changed
 	return admission.ValidationResponse(true, "")
 }
+
+// decodeRequest decodes the object and, if present, the old object of the given admission request. If decoding fails,
+// an error response is returned that should be passed back to the caller.
+func decodeRequest(req admission.Request, typesMap map[metav1.GroupVersionKind]runtime.Object, decoder *admission.Decoder) (runtime.Object, runtime.Object, metav1.Object, *admission.Response) {
+	ar := req.AdmissionRequest
+
+	// Decode object
+	t, ok := typesMap[ar.Kind]
+	if !ok {
+		resp := admission.Errored(http.StatusBadRequest, errors.Errorf("unexpected request kind %s", ar.Kind.String()))
+		return nil, nil, nil, &resp
+	}
+	obj := t.DeepCopyObject()
+	if err := decoder.Decode(req, obj); err != nil {
+		resp := admission.Errored(http.StatusBadRequest, errors.Wrapf(err, "could not decode request %v", ar))
+		return nil, nil, nil, &resp
+	}
+
+	// Get object accessor
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		resp := admission.Errored(http.StatusBadRequest, errors.Wrapf(err, "could not get accessor for %v", obj))
+		return nil, nil, nil, &resp
+	}
+
+	var oldObj runtime.Object
+
+	// Only UPDATE and DELETE operations have oldObjects.
+	if len(req.OldObject.Raw) != 0 {
+		oldObj = t.DeepCopyObject()
+		if err := decoder.DecodeRaw(ar.OldObject, oldObj); err != nil {
+			resp := admission.Errored(http.StatusBadRequest, errors.Wrapf(err, "could not decode old object %v", oldObj))
+			return nil, nil, nil, &resp
+		}
+	}
+
+	return obj, oldObj, accessor, nil
+}
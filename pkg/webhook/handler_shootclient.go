@@ -20,17 +20,13 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
-	"strings"
 
-	"github.com/gardener/gardener-extensions/pkg/util"
-
-	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	"k8s.io/api/admission/v1beta1"
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
-	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
@@ -46,6 +42,8 @@ var (
 )
 
 // NewHandlerWithShootClient creates a new handler for the given types, using the given mutator, and logger.
+// The shoot namespace of a request is looked up in a field index of the kube-apiserver pods by the remote IP of the
+// request, and the created shoot clients are cached for DefaultShootClientCacheTTL.
 func NewHandlerWithShootClient(mgr manager.Manager, types []runtime.Object, mutator MutatorWithShootClient, logger logr.Logger) (*handlerShootClient, error) {
 	// Build a map of the given types keyed by their GVKs
 	typesMap, err := buildTypesMap(mgr, types)
@@ -53,20 +51,32 @@ func NewHandlerWithShootClient(mgr manager.Manager, types []runtime.Object, muta
 		return nil, err
 	}
 
+	// Index the kube-apiserver pods by their IPs
+	if err := addKubeAPIServerPodIPIndex(mgr.GetFieldIndexer()); err != nil {
+		return nil, errors.Wrap(err, "could not add kube-apiserver pod IP index")
+	}
+
 	// Create and return a handler
 	return &handlerShootClient{
-		typesMap: typesMap,
-		mutator:  mutator,
-		logger:   logger.WithName("handlerShootClient"),
+		typesMap:     typesMap,
+		mutator:      mutator,
+		shootClients: newShootClientCache(DefaultShootClientCacheTTL),
+		logger:       logger.WithName("handlerShootClient"),
 	}, nil
 }
 
 type handlerShootClient struct {
-	typesMap map[metav1.GroupVersionKind]runtime.Object
-	mutator  MutatorWithShootClient
-	client   client.Client
-	decoder  *admission.Decoder
-	logger   logr.Logger
+	typesMap     map[metav1.GroupVersionKind]runtime.Object
+	mutator      MutatorWithShootClient
+	client       client.Client
+	shootClients *shootClientCache
+	decoder      *admission.Decoder
+	audit        auditor
+	logger       logr.Logger
+}
+
+func (h *handlerShootClient) injectAudit(webhook string, opts AuditOptions) {
+	h.audit = auditor{webhook: webhook, opts: opts}
 }
 
 // InjectDecoder injects the given decoder into the handler.
@@ -87,33 +97,20 @@ func (h *handlerShootClient) InjectClient(client client.Client) error {
 
 func (h *handlerShootClient) HandleWithRequest(ctx context.Context, req admission.Request, r *http.Request) admission.Response {
 	f := func(ctx context.Context, new, old runtime.Object, r *http.Request) error {
-		ipPort := strings.Split(r.RemoteAddr, ":")
-		if len(ipPort) < 1 {
+		ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
 			return fmt.Errorf("remote address not parseable: %s", r.RemoteAddr)
 		}
-		ip := ipPort[0]
-
-		podList := &corev1.PodList{}
-		if err := h.client.List(ctx, podList, client.MatchingLabels(map[string]string{
-			v1beta1constants.LabelApp:  v1beta1constants.LabelKubernetes,
-			v1beta1constants.LabelRole: v1beta1constants.LabelAPIServer,
-		})); err != nil {
-			return errors.Wrapf(err, "error while listing all pods")
-		}
 
-		var shootNamespace string
-		for _, pod := range podList.Items {
-			if pod.Status.PodIP == ip {
-				shootNamespace = pod.Namespace
-				break
-			}
+		shootNamespace, err := shootNamespaceForIP(ctx, h.client, ip)
+		if err != nil {
+			return err
 		}
-
 		if len(shootNamespace) == 0 {
 			return fmt.Errorf("could not find shoot namespace for webhook request")
 		}
 
-		_, shootClient, err := util.NewClientForShoot(ctx, h.client, shootNamespace, client.Options{})
+		shootClient, err := h.shootClients.get(ctx, h.client, shootNamespace)
 		if err != nil {
 			return errors.Wrapf(err, "could not create shoot client")
 		}
@@ -121,7 +118,7 @@ func (h *handlerShootClient) HandleWithRequest(ctx context.Context, req admissio
 		return h.mutator.Mutate(ctx, new, old, shootClient)
 	}
 
-	return handle(ctx, req, r, f, h.typesMap, h.decoder, h.logger)
+	return handle(ctx, req, r, f, h.typesMap, h.decoder, &h.audit, h.logger)
 }
 
 // ServeHTTP is a handler for serving an HTTP endpoint that is used for shoot webhooks.
@@ -16,6 +16,8 @@ package webhook
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -32,6 +34,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
@@ -175,6 +178,91 @@ var _ = Describe("Handler", func() {
 			}))
 		})
 
+		Context("auditing", func() {
+			const patch = `[{"op":"add","path":"/metadata/annotations","value":{"foo":"bar"}}]`
+
+			var (
+				recorder *record.FakeRecorder
+				h        *handler
+			)
+
+			BeforeEach(func() {
+				recorder = record.NewFakeRecorder(1)
+			})
+
+			newHandler := func(obj, oldObj runtime.Object) {
+				// Create mock mutator
+				mutator := mockwebhook.NewMockMutator(ctrl)
+				mutator.EXPECT().Mutate(context.TODO(), obj, oldObj).DoAndReturn(func(ctx context.Context, obj, oldOjb runtime.Object) error {
+					accessor, _ := meta.Accessor(obj)
+					accessor.SetAnnotations(map[string]string{"foo": "bar"})
+					return nil
+				})
+
+				// Create handler
+				wh, err := NewHandler(mgr, objTypes, mutator, logger)
+				Expect(err).NotTo(HaveOccurred())
+				err = wh.InjectDecoder(decoder)
+				Expect(err).NotTo(HaveOccurred())
+				h = wh
+			}
+
+			It("should record the patch hash in an annotation and the patch in an event", func() {
+				existingSvc := svc.DeepCopy()
+				existingSvc.UID = "1234"
+				newHandler(existingSvc, existingSvc)
+				InjectAudit(&Webhook{Name: "test", Target: TargetSeed, Webhook: &admission.Webhook{Handler: h}}, AuditOptions{Annotate: true, Recorder: recorder})
+
+				req.AdmissionRequest.Operation = admissionv1beta1.Update
+				req.AdmissionRequest.Object = runtime.RawExtension{Raw: encode(existingSvc)}
+				req.AdmissionRequest.OldObject = runtime.RawExtension{Raw: encode(existingSvc)}
+
+				// Call Handle and check response
+				resp := h.Handle(context.TODO(), req)
+				patchHash := sha256.Sum256([]byte(patch))
+				pt := admissionv1beta1.PatchTypeJSONPatch
+				Expect(resp).To(Equal(admission.Response{
+					Patches: []jsonpatch.JsonPatchOperation{
+						{
+							Operation: "add",
+							Path:      "/metadata/annotations",
+							Value: map[string]interface{}{
+								"foo": "bar",
+								AnnotationMutationPatchHashPrefix + "test": hex.EncodeToString(patchHash[:]),
+							},
+						},
+					},
+					AdmissionResponse: admissionv1beta1.AdmissionResponse{
+						Allowed:   true,
+						PatchType: &pt,
+					},
+				}))
+				Expect(recorder.Events).To(Receive(Equal(`Normal Mutated Webhook "test" applied patch ` + patch)))
+			})
+
+			It("should not record an event for objects that are being created", func() {
+				newHandler(svc, nil)
+				InjectAudit(&Webhook{Name: "test", Target: TargetSeed, Webhook: &admission.Webhook{Handler: h}}, AuditOptions{Recorder: recorder})
+
+				resp := h.Handle(context.TODO(), req)
+				Expect(resp.Allowed).To(BeTrue())
+				Expect(recorder.Events).NotTo(Receive())
+			})
+
+			It("should not record an event for shoot webhooks", func() {
+				existingSvc := svc.DeepCopy()
+				existingSvc.UID = "1234"
+				newHandler(existingSvc, nil)
+				InjectAudit(&Webhook{Name: "test", Target: TargetShoot, Webhook: &admission.Webhook{Handler: h}}, AuditOptions{Recorder: recorder})
+
+				req.AdmissionRequest.Object = runtime.RawExtension{Raw: encode(existingSvc)}
+
+				resp := h.Handle(context.TODO(), req)
+				Expect(resp.Allowed).To(BeTrue())
+				Expect(recorder.Events).NotTo(Receive())
+			})
+		})
+
 		It("should return an error response if the mutator returned an error", func() {
 			// Create mock mutator
 			mutator := mockwebhook.NewMockMutator(ctrl)
@@ -0,0 +1,86 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// NewValidatingHandler creates a new handler for the given types, using the given validator, and logger.
+func NewValidatingHandler(mgr manager.Manager, types []runtime.Object, validator Validator, logger logr.Logger) (*validatingHandler, error) {
+	// Build a map of the given types keyed by their GVKs
+	typesMap, err := buildTypesMap(mgr, types)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create and return a handler
+	return &validatingHandler{
+		typesMap:  typesMap,
+		validator: validator,
+		logger:    logger.WithName("validatingHandler"),
+	}, nil
+}
+
+type validatingHandler struct {
+	typesMap  map[metav1.GroupVersionKind]runtime.Object
+	validator Validator
+	decoder   *admission.Decoder
+	logger    logr.Logger
+}
+
+// InjectDecoder injects the given decoder into the handler.
+func (h *validatingHandler) InjectDecoder(d *admission.Decoder) error {
+	h.decoder = d
+	return nil
+}
+
+// InjectClient injects the given client into the validator.
+// TODO Replace this with the more generic InjectFunc when controller runtime supports it
+func (h *validatingHandler) InjectClient(client client.Client) error {
+	if _, err := inject.ClientInto(client, h.validator); err != nil {
+		return errors.Wrap(err, "could not inject the client into the validator")
+	}
+	return nil
+}
+
+// Handle handles the given admission request. It returns an allowing response if the validator does not return an
+// error, and a denying response containing the error as reason otherwise.
+func (h *validatingHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	ar := req.AdmissionRequest
+
+	obj, oldObj, accessor, errResp := decodeRequest(req, h.typesMap, h.decoder)
+	if errResp != nil {
+		return *errResp
+	}
+
+	// Validate the resource
+	if err := h.validator.Validate(ctx, obj, oldObj); err != nil {
+		h.logger.Info("Denying resource", "kind", ar.Kind.Kind, "namespace", accessor.GetNamespace(), "name", accessor.GetName(), "reason", err.Error())
+		return admission.Denied(fmt.Sprintf("%s %s/%s is invalid: %v", ar.Kind.Kind, accessor.GetNamespace(), accessor.GetName(), err))
+	}
+
+	return admission.Allowed("")
+}
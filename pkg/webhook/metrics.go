@@ -0,0 +1,52 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	mutationsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gardener_extensions_webhook_mutations_total",
+			Help: "Total number of mutations applied by a webhook.",
+		},
+		[]string{"webhook", "kind"},
+	)
+
+	mutationDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "gardener_extensions_webhook_mutation_duration_seconds",
+			Help:    "Duration of the mutation of an object by a webhook in seconds.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"webhook"},
+	)
+
+	mutationPatchSize = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "gardener_extensions_webhook_mutation_patch_size_bytes",
+			Help:    "Size of the JSON patches applied by a webhook in bytes.",
+			Buckets: prometheus.ExponentialBuckets(64, 2, 10),
+		},
+		[]string{"webhook"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(mutationsTotal, mutationDuration, mutationPatchSize)
+}
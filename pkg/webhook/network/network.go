@@ -15,11 +15,14 @@
 package network
 
 import (
+	"fmt"
+
 	"github.com/gardener/gardener-extensions/pkg/webhook"
 	extensionswebhook "github.com/gardener/gardener-extensions/pkg/webhook"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -43,14 +46,41 @@ type AddArgs struct {
 	Types []runtime.Object
 	// Mutator is a mutator to be used by the admission handler.
 	Mutator webhook.Mutator
+	// Validator is a validator to be used by the admission handler. If it is set, a validating webhook is created
+	// instead of a mutating one. Only one of Mutator and Validator can be set.
+	Validator webhook.Validator
+	// FailurePolicy is the failure policy of the webhook. If not set, the default failure policy of the target is used.
+	FailurePolicy *admissionregistrationv1beta1.FailurePolicyType
+	// TimeoutSeconds is the timeout of the webhook.
+	TimeoutSeconds *int32
+	// SideEffects states whether the webhook has side effects.
+	SideEffects *admissionregistrationv1beta1.SideEffectClass
+	// ReinvocationPolicy is the reinvocation policy of the webhook. It is only used for mutating webhooks.
+	ReinvocationPolicy *admissionregistrationv1beta1.ReinvocationPolicyType
 }
 
 // Add creates a new controlplane webhook and adds it to the given Manager.
 func Add(mgr manager.Manager, args AddArgs) (*webhook.Webhook, error) {
 	logger := logger.WithValues("network-provider", args.NetworkProvider, "cloud-provider", args.CloudProvider)
 
+	var (
+		name    = WebhookName
+		action  = extensionswebhook.ActionMutating
+		handler admission.Handler
+		err     error
+	)
+
 	// Create handler
-	handler, err := webhook.NewHandler(mgr, args.Types, args.Mutator, logger)
+	switch {
+	case args.Mutator != nil && args.Validator != nil:
+		return nil, fmt.Errorf("only one of mutator and validator can be set")
+	case args.Validator != nil:
+		name += extensionswebhook.ValidatingSuffix
+		action = extensionswebhook.ActionValidating
+		handler, err = webhook.NewValidatingHandler(mgr, args.Types, args.Validator, logger)
+	default:
+		handler, err = webhook.NewHandler(mgr, args.Types, args.Mutator, logger)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -62,20 +92,21 @@ func Add(mgr manager.Manager, args AddArgs) (*webhook.Webhook, error) {
 	}
 
 	// Create webhook
-	var (
-		name = WebhookName
-		path = WebhookName
-	)
-
 	logger.Info("Creating network webhook", "name", name)
 	return &extensionswebhook.Webhook{
 		Name:     name,
 		Provider: args.NetworkProvider,
 		Types:    args.Types,
 		Target:   extensionswebhook.TargetSeed,
-		Path:     path,
+		Action:   action,
+		Path:     name,
 		Webhook:  &admission.Webhook{Handler: handler},
 		Selector: namespaceSelector,
+
+		FailurePolicy:      args.FailurePolicy,
+		TimeoutSeconds:     args.TimeoutSeconds,
+		SideEffects:        args.SideEffects,
+		ReinvocationPolicy: args.ReinvocationPolicy,
 	}, nil
 
 }
@@ -18,25 +18,120 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
 	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
-// RegisterWebhooks registers the given webhooks in the Kubernetes cluster targeted by the provided manager.
-func RegisterWebhooks(ctx context.Context, mgr manager.Manager, namespace, providerName string, port int, mode, url string, caBundle []byte, webhooks []*Webhook) (webhooksToRegisterSeed []admissionregistrationv1beta1.MutatingWebhook, webhooksToRegisterShoot []admissionregistrationv1beta1.MutatingWebhook, err error) {
+var (
+	fail   = admissionregistrationv1beta1.Fail
+	ignore = admissionregistrationv1beta1.Ignore
+)
+
+// Configs contains the webhooks that shall be registered for a target, separated by their action. It is safe for
+// concurrent use, the CA bundle of the contained webhooks can be updated at runtime via InjectCABundle.
+type Configs struct {
+	// MutatingWebhooks are the webhooks to register in a MutatingWebhookConfiguration.
+	MutatingWebhooks []admissionregistrationv1beta1.MutatingWebhook
+	// ValidatingWebhooks are the webhooks to register in a ValidatingWebhookConfiguration.
+	ValidatingWebhooks []admissionregistrationv1beta1.ValidatingWebhook
+
+	lock       sync.RWMutex
+	safetyMode bool
+}
+
+// IsEmpty returns true if neither mutating nor validating webhooks are contained in the configs.
+func (c *Configs) IsEmpty() bool {
+	if c == nil {
+		return true
+	}
+
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return len(c.MutatingWebhooks) == 0 && len(c.ValidatingWebhooks) == 0
+}
+
+// Get returns deep copies of the contained mutating and validating webhooks. If the safety mode is enabled, the failure
+// policy of all webhooks is Ignore.
+func (c *Configs) Get() ([]admissionregistrationv1beta1.MutatingWebhook, []admissionregistrationv1beta1.ValidatingWebhook) {
+	if c == nil {
+		return nil, nil
+	}
+
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
 	var (
-		fail                             = admissionregistrationv1beta1.Fail
-		ignore                           = admissionregistrationv1beta1.Ignore
-		mutatingWebhookConfigurationSeed = &admissionregistrationv1beta1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "gardener-extension-" + providerName}}
+		mutatingWebhooks   []admissionregistrationv1beta1.MutatingWebhook
+		validatingWebhooks []admissionregistrationv1beta1.ValidatingWebhook
 	)
 
+	for _, w := range c.MutatingWebhooks {
+		webhook := w.DeepCopy()
+		if c.safetyMode {
+			webhook.FailurePolicy = &ignore
+		}
+		mutatingWebhooks = append(mutatingWebhooks, *webhook)
+	}
+	for _, w := range c.ValidatingWebhooks {
+		webhook := w.DeepCopy()
+		if c.safetyMode {
+			webhook.FailurePolicy = &ignore
+		}
+		validatingWebhooks = append(validatingWebhooks, *webhook)
+	}
+
+	return mutatingWebhooks, validatingWebhooks
+}
+
+// SetSafetyMode enables or disables the safety mode. It returns true if the safety mode has been changed.
+func (c *Configs) SetSafetyMode(enabled bool) bool {
+	if c == nil {
+		return false
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	changed := c.safetyMode != enabled
+	c.safetyMode = enabled
+	return changed
+}
+
+// InjectCABundle sets the given CA bundle in the client configs of all contained webhooks.
+func (c *Configs) InjectCABundle(caBundle []byte) {
+	if c == nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for i := range c.MutatingWebhooks {
+		c.MutatingWebhooks[i].ClientConfig.CABundle = caBundle
+	}
+	for i := range c.ValidatingWebhooks {
+		c.ValidatingWebhooks[i].ClientConfig.CABundle = caBundle
+	}
+}
+
+// RegisterWebhooks registers the given webhooks in the Kubernetes cluster targeted by the provided manager.
+func RegisterWebhooks(ctx context.Context, mgr manager.Manager, namespace, providerName string, port int, mode, url string, caBundle []byte, webhooks []*Webhook) (webhooksToRegisterSeed *Configs, webhooksToRegisterShoot *Configs, err error) {
+	webhooksToRegisterSeed, webhooksToRegisterShoot = &Configs{}, &Configs{}
+
 	for _, webhook := range webhooks {
+		if webhook.Action == ActionConversion {
+			continue
+		}
+
 		var rules []admissionregistrationv1beta1.RuleWithOperations
 		for _, t := range webhook.Types {
 			rule, err := buildRule(mgr, t)
@@ -46,36 +141,64 @@ func RegisterWebhooks(ctx context.Context, mgr manager.Manager, namespace, provi
 			rules = append(rules, *rule)
 		}
 
-		webhookToRegister := admissionregistrationv1beta1.MutatingWebhook{
-			Name:              fmt.Sprintf("%s.%s.extensions.gardener.cloud", webhook.Name, strings.TrimPrefix(providerName, "provider-")),
-			NamespaceSelector: webhook.Selector,
-			Rules:             rules,
-		}
+		var (
+			name          = fmt.Sprintf("%s.%s.extensions.gardener.cloud", webhook.Name, strings.TrimPrefix(providerName, "provider-"))
+			failurePolicy *admissionregistrationv1beta1.FailurePolicyType
+			clientConfig  admissionregistrationv1beta1.WebhookClientConfig
+			configs       *Configs
+		)
 
 		switch webhook.Target {
 		case TargetSeed:
-			webhookToRegister.FailurePolicy = &fail
-			webhookToRegister.ClientConfig = buildClientConfigFor(webhook, namespace, providerName, port, mode, url, caBundle)
-			webhooksToRegisterSeed = append(webhooksToRegisterSeed, webhookToRegister)
+			failurePolicy = &fail
+			clientConfig = buildClientConfigFor(webhook, namespace, providerName, port, mode, url, caBundle)
+			configs = webhooksToRegisterSeed
 		case TargetShoot:
-			webhookToRegister.FailurePolicy = &ignore
-			webhookToRegister.ClientConfig = buildClientConfigFor(webhook, namespace, providerName, port, ModeURLWithServiceName, url, caBundle)
-			webhooksToRegisterShoot = append(webhooksToRegisterShoot, webhookToRegister)
+			failurePolicy = &ignore
+			clientConfig = buildClientConfigFor(webhook, namespace, providerName, port, ModeURLWithServiceName, url, caBundle)
+			configs = webhooksToRegisterShoot
 		default:
 			return nil, nil, fmt.Errorf("invalid webhook target: %s", webhook.Target)
 		}
+
+		if webhook.FailurePolicy != nil {
+			failurePolicy = webhook.FailurePolicy
+		}
+
+		switch webhook.Action {
+		case ActionMutating, "":
+			configs.MutatingWebhooks = append(configs.MutatingWebhooks, admissionregistrationv1beta1.MutatingWebhook{
+				Name:               name,
+				NamespaceSelector:  webhook.Selector,
+				Rules:              rules,
+				FailurePolicy:      failurePolicy,
+				ClientConfig:       clientConfig,
+				TimeoutSeconds:     webhook.TimeoutSeconds,
+				SideEffects:        webhook.SideEffects,
+				ReinvocationPolicy: webhook.ReinvocationPolicy,
+			})
+		case ActionValidating:
+			configs.ValidatingWebhooks = append(configs.ValidatingWebhooks, admissionregistrationv1beta1.ValidatingWebhook{
+				Name:              name,
+				NamespaceSelector: webhook.Selector,
+				Rules:             rules,
+				FailurePolicy:     failurePolicy,
+				ClientConfig:      clientConfig,
+				TimeoutSeconds:    webhook.TimeoutSeconds,
+				SideEffects:       webhook.SideEffects,
+			})
+		default:
+			return nil, nil, fmt.Errorf("invalid webhook action: %s", webhook.Action)
+		}
 	}
 
-	if len(webhooksToRegisterSeed) > 0 {
+	if !webhooksToRegisterSeed.IsEmpty() {
 		c, err := getClient(mgr)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		if _, err := controllerutil.CreateOrUpdate(ctx, c, mutatingWebhookConfigurationSeed, func() error {
-			mutatingWebhookConfigurationSeed.Webhooks = webhooksToRegisterSeed
-			return nil
-		}); err != nil {
+		if err := ReconcileSeedWebhookConfigs(ctx, c, providerName, webhooksToRegisterSeed); err != nil {
 			return nil, nil, err
 		}
 	}
@@ -83,6 +206,37 @@ func RegisterWebhooks(ctx context.Context, mgr manager.Manager, namespace, provi
 	return webhooksToRegisterSeed, webhooksToRegisterShoot, nil
 }
 
+// ReconcileSeedWebhookConfigs creates or updates the MutatingWebhookConfiguration and the ValidatingWebhookConfiguration
+// of the given provider in the seed, containing the given webhooks.
+func ReconcileSeedWebhookConfigs(ctx context.Context, c client.Client, providerName string, configs *Configs) error {
+	var (
+		mutatingWebhookConfiguration   = &admissionregistrationv1beta1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "gardener-extension-" + providerName}}
+		validatingWebhookConfiguration = &admissionregistrationv1beta1.ValidatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "gardener-extension-" + providerName}}
+
+		mutatingWebhooks, validatingWebhooks = configs.Get()
+	)
+
+	if len(mutatingWebhooks) > 0 {
+		if _, err := controllerutil.CreateOrUpdate(ctx, c, mutatingWebhookConfiguration, func() error {
+			mutatingWebhookConfiguration.Webhooks = mutatingWebhooks
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	if len(validatingWebhooks) > 0 {
+		if _, err := controllerutil.CreateOrUpdate(ctx, c, validatingWebhookConfiguration, func() error {
+			validatingWebhookConfiguration.Webhooks = validatingWebhooks
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // buildRule creates and returns a RuleWithOperations for the given object type.
 func buildRule(mgr manager.Manager, t runtime.Object) (*admissionregistrationv1beta1.RuleWithOperations, error) {
 	// Get GVK from the type
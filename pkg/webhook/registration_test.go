@@ -0,0 +1,72 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+)
+
+var _ = Describe("Configs", func() {
+	var configs *Configs
+
+	BeforeEach(func() {
+		configs = &Configs{
+			MutatingWebhooks: []admissionregistrationv1beta1.MutatingWebhook{
+				{Name: "mutating", FailurePolicy: &fail},
+			},
+			ValidatingWebhooks: []admissionregistrationv1beta1.ValidatingWebhook{
+				{Name: "validating", FailurePolicy: &fail},
+			},
+		}
+	})
+
+	Describe("#IsEmpty", func() {
+		It("should return true for nil or empty configs", func() {
+			var nilConfigs *Configs
+			Expect(nilConfigs.IsEmpty()).To(BeTrue())
+			Expect((&Configs{}).IsEmpty()).To(BeTrue())
+			Expect(configs.IsEmpty()).To(BeFalse())
+		})
+	})
+
+	Describe("#InjectCABundle", func() {
+		It("should set the CA bundle in all webhooks", func() {
+			configs.InjectCABundle([]byte("ca"))
+
+			mutatingWebhooks, validatingWebhooks := configs.Get()
+			Expect(mutatingWebhooks[0].ClientConfig.CABundle).To(Equal([]byte("ca")))
+			Expect(validatingWebhooks[0].ClientConfig.CABundle).To(Equal([]byte("ca")))
+		})
+	})
+
+	Describe("#SetSafetyMode", func() {
+		It("should switch the failure policies to Ignore while the safety mode is enabled", func() {
+			Expect(configs.SetSafetyMode(true)).To(BeTrue())
+			Expect(configs.SetSafetyMode(true)).To(BeFalse())
+
+			mutatingWebhooks, validatingWebhooks := configs.Get()
+			Expect(*mutatingWebhooks[0].FailurePolicy).To(Equal(admissionregistrationv1beta1.Ignore))
+			Expect(*validatingWebhooks[0].FailurePolicy).To(Equal(admissionregistrationv1beta1.Ignore))
+
+			Expect(configs.SetSafetyMode(false)).To(BeTrue())
+
+			mutatingWebhooks, validatingWebhooks = configs.Get()
+			Expect(*mutatingWebhooks[0].FailurePolicy).To(Equal(admissionregistrationv1beta1.Fail))
+			Expect(*validatingWebhooks[0].FailurePolicy).To(Equal(admissionregistrationv1beta1.Fail))
+		})
+	})
+})
@@ -0,0 +1,133 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"time"
+
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// DefaultSafetyModeCheckInterval is the default interval in which the SafetyModeGuard checks the health of the webhook
+// server pods.
+const DefaultSafetyModeCheckInterval = 30 * time.Second
+
+// NewSafetyModeGuard creates a new SafetyModeGuard for the seed webhooks of the given provider.
+func NewSafetyModeGuard(mgr manager.Manager, namespace, providerName string, seedWebhooks *Configs, checkInterval time.Duration, logger logr.Logger) *SafetyModeGuard {
+	return &SafetyModeGuard{
+		mgr:           mgr,
+		namespace:     namespace,
+		providerName:  providerName,
+		seedWebhooks:  seedWebhooks,
+		checkInterval: checkInterval,
+		logger:        logger.WithName("safety-mode-guard"),
+	}
+}
+
+// SafetyModeGuard periodically checks whether the webhook server pods of the extension are healthy, i.e., whether the
+// webhook service has ready endpoints. If not, it enables the safety mode of the seed webhook configs which switches
+// the failure policy of all seed webhooks to Ignore, so that a broken extension does not block updates of the objects
+// its webhooks are registered for. Once the pods are healthy again, the configured failure policies are restored.
+// The guard can only be used if the webhooks are served via the webhook service, i.e., in 'service' mode.
+//
+// Note that the guard runs in the extension pods itself. Hence, it only takes effect as long as at least one extension
+// pod is running, e.g. if the webhook server pods are not ready or are being rolled. If all extension pods are down,
+// nothing switches the failure policies to Ignore and the configured failure policies apply.
+type SafetyModeGuard struct {
+	mgr           manager.Manager
+	namespace     string
+	providerName  string
+	seedWebhooks  *Configs
+	checkInterval time.Duration
+	logger        logr.Logger
+
+	client client.Client
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. The guard only runs in the leading replica so that
+// multiple replicas do not fight over the seed webhook configurations.
+func (g *SafetyModeGuard) NeedLeaderElection() bool {
+	return true
+}
+
+// Start implements manager.Runnable.
+func (g *SafetyModeGuard) Start(stopCh <-chan struct{}) error {
+	if g.seedWebhooks.IsEmpty() {
+		return nil
+	}
+
+	c, err := getClient(g.mgr)
+	if err != nil {
+		return err
+	}
+	g.client = c
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stopCh
+		cancel()
+	}()
+
+	wait.Until(func() {
+		if err := g.reconcile(ctx); err != nil {
+			g.logger.Error(err, "Could not check the health of the webhook server pods")
+		}
+	}, g.checkInterval, stopCh)
+	return nil
+}
+
+func (g *SafetyModeGuard) reconcile(ctx context.Context) error {
+	endpoints := &corev1.Endpoints{}
+	if err := g.client.Get(ctx, kutil.Key(g.namespace, "gardener-extension-"+g.providerName), endpoints); client.IgnoreNotFound(err) != nil {
+		return errors.Wrapf(err, "could not get endpoints of webhook service")
+	}
+
+	healthy := hasReadyAddresses(endpoints)
+	changed := g.seedWebhooks.SetSafetyMode(!healthy)
+	switch {
+	case changed && healthy:
+		g.logger.Info("Webhook server pods are healthy again, restoring failure policies of seed webhooks")
+	case changed && !healthy:
+		g.logger.Info("Webhook server pods are unhealthy, switching failure policies of seed webhooks to Ignore")
+	case healthy:
+		return nil
+	}
+	// While the safety mode is enabled, the configs are updated with every check as other replicas register the
+	// webhooks with the configured failure policies when they are (re)started.
+
+	if err := ReconcileSeedWebhookConfigs(ctx, g.client, g.providerName, g.seedWebhooks); err != nil {
+		// Revert the safety mode so that the webhook configurations are updated with the next check.
+		g.seedWebhooks.SetSafetyMode(healthy)
+		return errors.Wrapf(err, "could not update seed webhook configurations")
+	}
+	return nil
+}
+
+func hasReadyAddresses(endpoints *corev1.Endpoints) bool {
+	for _, subset := range endpoints.Subsets {
+		if len(subset.Addresses) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Safety mode", func() {
+	const (
+		namespace    = "extension-foo"
+		providerName = "provider-foo"
+	)
+
+	var (
+		ctx   = context.TODO()
+		c     client.Client
+		guard *SafetyModeGuard
+	)
+
+	BeforeEach(func() {
+		c = fakeclient.NewFakeClientWithScheme(scheme.Scheme)
+		seedWebhooks := &Configs{MutatingWebhooks: []admissionregistrationv1beta1.MutatingWebhook{{Name: "mutating", FailurePolicy: &fail}}}
+		guard = NewSafetyModeGuard(nil, namespace, providerName, seedWebhooks, DefaultSafetyModeCheckInterval, log.Log.WithName("test"))
+		guard.client = c
+	})
+
+	failurePolicy := func() admissionregistrationv1beta1.FailurePolicyType {
+		config := &admissionregistrationv1beta1.MutatingWebhookConfiguration{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "gardener-extension-" + providerName}, config)).To(Succeed())
+		return *config.Webhooks[0].FailurePolicy
+	}
+
+	It("should need leader election", func() {
+		Expect(guard.NeedLeaderElection()).To(BeTrue())
+	})
+
+	It("should keep the failure policies on Ignore while the webhook server pods are unhealthy", func() {
+		Expect(guard.reconcile(ctx)).To(Succeed())
+		Expect(failurePolicy()).To(Equal(ignore))
+
+		// another replica registers the webhooks with the configured failure policies on startup
+		Expect(ReconcileSeedWebhookConfigs(ctx, c, providerName, &Configs{MutatingWebhooks: []admissionregistrationv1beta1.MutatingWebhook{{Name: "mutating", FailurePolicy: &fail}}})).To(Succeed())
+
+		Expect(guard.reconcile(ctx)).To(Succeed())
+		Expect(failurePolicy()).To(Equal(ignore))
+	})
+
+	It("should restore the failure policies once the webhook server pods are healthy again", func() {
+		Expect(guard.reconcile(ctx)).To(Succeed())
+
+		Expect(c.Create(ctx, &corev1.Endpoints{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "gardener-extension-" + providerName},
+			Subsets:    []corev1.EndpointSubset{{Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}}}},
+		})).To(Succeed())
+
+		Expect(guard.reconcile(ctx)).To(Succeed())
+		Expect(failurePolicy()).To(Equal(fail))
+	})
+})
@@ -20,6 +20,7 @@ import (
 	extensionswebhook "github.com/gardener/gardener-extensions/pkg/webhook"
 
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -44,6 +45,17 @@ type AddArgs struct {
 	Mutator extensionswebhook.Mutator
 	// MutatorWithShootClient is a mutator to be used by the admission handler. It needs the shoot client.
 	MutatorWithShootClient extensionswebhook.MutatorWithShootClient
+	// Validator is a validator to be used by the admission handler. If it is set, a validating webhook is created
+	// instead of a mutating one. Only one of Mutator, MutatorWithShootClient and Validator can be set.
+	Validator extensionswebhook.Validator
+	// FailurePolicy is the failure policy of the webhook. If not set, the default failure policy of the target is used.
+	FailurePolicy *admissionregistrationv1beta1.FailurePolicyType
+	// TimeoutSeconds is the timeout of the webhook.
+	TimeoutSeconds *int32
+	// SideEffects states whether the webhook has side effects.
+	SideEffects *admissionregistrationv1beta1.SideEffectClass
+	// ReinvocationPolicy is the reinvocation policy of the webhook. It is only used for mutating webhooks.
+	ReinvocationPolicy *admissionregistrationv1beta1.ReinvocationPolicyType
 }
 
 // Add creates a new shoot webhook and adds it to the given Manager.
@@ -61,10 +73,19 @@ func Add(mgr manager.Manager, args AddArgs) (*extensionswebhook.Webhook, error)
 		Types:    args.Types,
 		Path:     WebhookName,
 		Target:   extensionswebhook.TargetShoot,
+		Action:   extensionswebhook.ActionMutating,
 		Selector: namespaceSelector,
+
+		FailurePolicy:      args.FailurePolicy,
+		TimeoutSeconds:     args.TimeoutSeconds,
+		SideEffects:        args.SideEffects,
+		ReinvocationPolicy: args.ReinvocationPolicy,
 	}
 
 	switch {
+	case args.Validator != nil && (args.Mutator != nil || args.MutatorWithShootClient != nil):
+		return nil, fmt.Errorf("only one of mutator, mutator with shoot client and validator can be set")
+
 	case args.Mutator != nil:
 		handler, err := extensionswebhook.NewHandler(mgr, args.Types, args.Mutator, logger)
 		if err != nil {
@@ -90,9 +111,21 @@ func Add(mgr manager.Manager, args AddArgs) (*extensionswebhook.Webhook, error)
 
 		wh.Handler = handler
 		return wh, nil
+
+	case args.Validator != nil:
+		handler, err := extensionswebhook.NewValidatingHandler(mgr, args.Types, args.Validator, logger)
+		if err != nil {
+			return nil, err
+		}
+
+		wh.Name += extensionswebhook.ValidatingSuffix
+		wh.Path += extensionswebhook.ValidatingSuffix
+		wh.Action = extensionswebhook.ActionValidating
+		wh.Webhook = &admission.Webhook{Handler: handler}
+		return wh, nil
 	}
 
-	return nil, fmt.Errorf("neither mutator nor mutator with shoot client is set")
+	return nil, fmt.Errorf("neither mutator, mutator with shoot client nor validator is set")
 }
 
 // buildSelector creates and returns a LabelSelector for the given webhook kind and provider.
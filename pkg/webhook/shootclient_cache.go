@@ -0,0 +1,153 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gardener/gardener-extensions/pkg/util"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultShootClientCacheTTL is the default duration for which shoot clients are cached.
+	DefaultShootClientCacheTTL = 10 * time.Minute
+
+	// podIPIndexField is the name of the field index that maps the IPs of kube-apiserver pods to these pods.
+	podIPIndexField = "status.podIP"
+)
+
+var (
+	indexedFieldIndexersLock sync.Mutex
+	indexedFieldIndexers     = map[client.FieldIndexer]struct{}{}
+)
+
+// addKubeAPIServerPodIPIndex adds a field index mapping the IPs of kube-apiserver pods to these pods to the given field
+// indexer, unless it was already added before.
+func addKubeAPIServerPodIPIndex(indexer client.FieldIndexer) error {
+	indexedFieldIndexersLock.Lock()
+	defer indexedFieldIndexersLock.Unlock()
+
+	if _, ok := indexedFieldIndexers[indexer]; ok {
+		return nil
+	}
+
+	if err := indexer.IndexField(&corev1.Pod{}, podIPIndexField, kubeAPIServerPodIPIndexerFunc); err != nil {
+		return err
+	}
+
+	indexedFieldIndexers[indexer] = struct{}{}
+	return nil
+}
+
+func kubeAPIServerPodIPIndexerFunc(obj runtime.Object) []string {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok || len(pod.Status.PodIP) == 0 {
+		return nil
+	}
+	if pod.Labels[v1beta1constants.LabelApp] != v1beta1constants.LabelKubernetes || pod.Labels[v1beta1constants.LabelRole] != v1beta1constants.LabelAPIServer {
+		return nil
+	}
+	return []string{pod.Status.PodIP}
+}
+
+// shootNamespaceForIP returns the namespace of the kube-apiserver pod with the given IP, using the field index of the
+// given client. If no such pod exists, an empty string is returned.
+func shootNamespaceForIP(ctx context.Context, c client.Client, ip string) (string, error) {
+	podList := &corev1.PodList{}
+	if err := c.List(ctx, podList, client.MatchingFields{podIPIndexField: ip}); err != nil {
+		return "", errors.Wrapf(err, "error while listing kube-apiserver pods with IP %s", ip)
+	}
+
+	if len(podList.Items) == 0 {
+		return "", nil
+	}
+	return podList.Items[0].Namespace, nil
+}
+
+type shootClientCacheEntry struct {
+	client          client.Client
+	secretName      string
+	resourceVersion string
+	expiration      time.Time
+}
+
+// shootClientCache caches clients for shoot clusters keyed by the shoot namespace. A cached client is invalidated after
+// the TTL expired or as soon as the secret containing its kubeconfig changed.
+type shootClientCache struct {
+	ttl       time.Duration
+	newClient func(secret *corev1.Secret) (client.Client, error)
+	now       func() time.Time
+
+	lock    sync.Mutex
+	entries map[string]*shootClientCacheEntry
+}
+
+func newShootClientCache(ttl time.Duration) *shootClientCache {
+	return &shootClientCache{
+		ttl: ttl,
+		newClient: func(secret *corev1.Secret) (client.Client, error) {
+			_, c, err := util.NewClientForShootFromSecret(secret, client.Options{})
+			return c, err
+		},
+		now:     time.Now,
+		entries: map[string]*shootClientCacheEntry{},
+	}
+}
+
+// get returns a client for the shoot cluster in the given shoot namespace. The secret containing the kubeconfig is read
+// with the given seed client on every call, so that a cached client is replaced as soon as the kubeconfig is rotated.
+func (s *shootClientCache) get(ctx context.Context, c client.Client, namespace string) (client.Client, error) {
+	secret, err := util.GetKubeconfigSecretForShoot(ctx, c, namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get kubeconfig secret for shoot namespace %s", namespace)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := s.now()
+	if entry, ok := s.entries[namespace]; ok && now.Before(entry.expiration) &&
+		entry.secretName == secret.Name && entry.resourceVersion == secret.ResourceVersion {
+		return entry.client, nil
+	}
+
+	shootClient, err := s.newClient(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	// Remove expired entries, e.g. of shoots that were deleted in the meantime
+	for ns, entry := range s.entries {
+		if !now.Before(entry.expiration) {
+			delete(s.entries, ns)
+		}
+	}
+
+	s.entries[namespace] = &shootClientCacheEntry{
+		client:          shootClient,
+		secretName:      secret.Name,
+		resourceVersion: secret.ResourceVersion,
+		expiration:      now.Add(s.ttl),
+	}
+	return shootClient, nil
+}
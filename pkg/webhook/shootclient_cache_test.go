@@ -0,0 +1,126 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"time"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Shoot client cache", func() {
+	const namespace = "shoot--foo--bar"
+
+	Describe("#kubeAPIServerPodIPIndexerFunc", func() {
+		It("should index kube-apiserver pods by their IP", func() {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      "kube-apiserver",
+					Labels: map[string]string{
+						v1beta1constants.LabelApp:  v1beta1constants.LabelKubernetes,
+						v1beta1constants.LabelRole: v1beta1constants.LabelAPIServer,
+					},
+				},
+				Status: corev1.PodStatus{PodIP: "10.0.0.1"},
+			}
+
+			Expect(kubeAPIServerPodIPIndexerFunc(pod)).To(Equal([]string{"10.0.0.1"}))
+		})
+
+		It("should not index other pods", func() {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "etcd"},
+				Status:     corev1.PodStatus{PodIP: "10.0.0.2"},
+			}
+
+			Expect(kubeAPIServerPodIPIndexerFunc(pod)).To(BeEmpty())
+		})
+	})
+
+	Describe("#get", func() {
+		var (
+			ctx     = context.TODO()
+			c       client.Client
+			secret  *corev1.Secret
+			cache   *shootClientCache
+			now     time.Time
+			created int
+		)
+
+		BeforeEach(func() {
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: v1beta1constants.SecretNameGardener},
+				Data:       map[string][]byte{"kubeconfig": []byte("foo")},
+			}
+			c = fake.NewFakeClient(secret)
+
+			now = time.Now()
+			created = 0
+			cache = newShootClientCache(time.Minute)
+			cache.now = func() time.Time { return now }
+			cache.newClient = func(*corev1.Secret) (client.Client, error) {
+				created++
+				return fake.NewFakeClient(), nil
+			}
+		})
+
+		It("should return the cached client", func() {
+			first, err := cache.get(ctx, c, namespace)
+			Expect(err).NotTo(HaveOccurred())
+			second, err := cache.get(ctx, c, namespace)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(second).To(BeIdenticalTo(first))
+			Expect(created).To(Equal(1))
+		})
+
+		It("should create a new client after the TTL expired", func() {
+			_, err := cache.get(ctx, c, namespace)
+			Expect(err).NotTo(HaveOccurred())
+
+			now = now.Add(2 * time.Minute)
+			_, err = cache.get(ctx, c, namespace)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(created).To(Equal(2))
+		})
+
+		It("should create a new client if the kubeconfig secret was rotated", func() {
+			_, err := cache.get(ctx, c, namespace)
+			Expect(err).NotTo(HaveOccurred())
+
+			secret.Data["kubeconfig"] = []byte("bar")
+			Expect(c.Update(ctx, secret)).To(Succeed())
+			_, err = cache.get(ctx, c, namespace)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(created).To(Equal(2))
+		})
+
+		It("should fail if there is no kubeconfig secret", func() {
+			_, err := cache.get(ctx, fake.NewFakeClient(), namespace)
+			Expect(err).To(HaveOccurred())
+			Expect(created).To(Equal(0))
+		})
+	})
+})